@@ -54,11 +54,12 @@ func SetLocale(category int, locale string) string {
 // Mpv represents an mpv client.
 type Mpv struct {
 	handle *C.mpv_handle
+	state  *clientState
 }
 
 // New creates a new mpv instance and an associated client API handle.
 func New() *Mpv {
-	return &Mpv{C.mpv_create()}
+	return &Mpv{handle: C.mpv_create(), state: newClientState()}
 }
 
 // APIVersion returns the client api version the mpv source has been compiled with.
@@ -78,17 +79,58 @@ func (m *Mpv) ID() int64 {
 
 // Initialize initializes an uninitialized mpv instance.
 func (m *Mpv) Initialize() error {
-	return newError(int(C.mpv_initialize(m.handle)))
+	return opError(int(C.mpv_initialize(m.handle)), "initialize", "", nil)
 }
 
 // TerminateDestroy terminates mpv and destroys the client.
+// All other clients of the same core, strong or weak, receive EventShutdown and must call Destroy.
 func (m *Mpv) TerminateDestroy() {
 	C.mpv_terminate_destroy(m.handle)
+	m.failFutures(errClientShutdown)
+	m.unregisterProtocols()
 }
 
 // Destroy disconnects and destroys this client handle without terminating mpv.
+// The core is terminated once the last strong client handle has been destroyed.
 func (m *Mpv) Destroy() {
 	C.mpv_destroy(m.handle)
+	m.failFutures(errClientShutdown)
+	m.unregisterProtocols()
+}
+
+// CreateClient creates a new client handle connected to the same mpv core, with its
+// own event queue and reply userdata space. An empty name lets mpv choose a unique one.
+// The new handle keeps the core alive until it is destroyed with Destroy or TerminateDestroy.
+func (m *Mpv) CreateClient(name string) (*Mpv, error) {
+	var cname *C.char
+	if name != "" {
+		cname = C.CString(name)
+		defer C.free(unsafe.Pointer(cname))
+	}
+
+	handle := C.mpv_create_client(m.handle, cname)
+	if handle == nil {
+		return nil, ErrGeneric
+	}
+
+	return &Mpv{handle: handle, state: newClientState()}, nil
+}
+
+// CreateWeakClient is like CreateClient, but the handle does not keep the core alive.
+// When the last strong handle is destroyed, the weak client receives EventShutdown and must call Destroy.
+func (m *Mpv) CreateWeakClient(name string) (*Mpv, error) {
+	var cname *C.char
+	if name != "" {
+		cname = C.CString(name)
+		defer C.free(unsafe.Pointer(cname))
+	}
+
+	handle := C.mpv_create_weak_client(m.handle, cname)
+	if handle == nil {
+		return nil, ErrGeneric
+	}
+
+	return &Mpv{handle: handle, state: newClientState()}, nil
 }
 
 // LoadConfigFile loads the given config file.
@@ -96,7 +138,7 @@ func (m *Mpv) LoadConfigFile(fileName string) error {
 	cfileName := C.CString(fileName)
 	defer C.free(unsafe.Pointer(cfileName))
 
-	return newError(int(C.mpv_load_config_file(m.handle, cfileName)))
+	return opError(int(C.mpv_load_config_file(m.handle, cfileName)), "load_config_file", fileName, nil)
 }
 
 // TimeUS returns the internal time in microseconds.
@@ -111,13 +153,17 @@ func (m *Mpv) TimeNS() int64 {
 
 // SetOption sets the given option according to the given format.
 func (m *Mpv) SetOption(name string, format Format, data interface{}) error {
+	if err := checkData(format, data); err != nil {
+		return err
+	}
+
 	cname := C.CString(name)
 	defer C.free(unsafe.Pointer(cname))
 
 	cdata, cleanup := convertData(format, data)
 	defer cleanup()
 
-	return newError(int(C.mpv_set_option(m.handle, cname, C.mpv_format(format), cdata)))
+	return opError(int(C.mpv_set_option(m.handle, cname, C.mpv_format(format), cdata)), "set_option", name, nil)
 }
 
 // SetOptionString sets the option to the given string.
@@ -127,7 +173,7 @@ func (m *Mpv) SetOptionString(name, value string) error {
 	cvalue := C.CString(value)
 	defer C.free(unsafe.Pointer(cvalue))
 
-	return newError(int(C.mpv_set_option_string(m.handle, cname, cvalue)))
+	return opError(int(C.mpv_set_option_string(m.handle, cname, cvalue)), "set_option_string", name, nil)
 }
 
 // Command runs the specified command, returning an error if something goes wrong.
@@ -144,7 +190,7 @@ func (m *Mpv) Command(cmd []string) error {
 		C.setStringArray(arr, C.int(i), cs)
 	}
 
-	return newError(int(C.mpv_command(m.handle, arr)))
+	return opError(int(C.mpv_command(m.handle, arr)), "command", "", cmd)
 }
 
 // CommandString runs the given command string, this string is parsed internally by mpv.
@@ -152,7 +198,7 @@ func (m *Mpv) CommandString(cmd string) error {
 	ccmd := C.CString(cmd)
 	defer C.free(unsafe.Pointer(ccmd))
 
-	return newError(int(C.mpv_command_string(m.handle, ccmd)))
+	return opError(int(C.mpv_command_string(m.handle, ccmd)), "command_string", "", []string{cmd})
 }
 
 // CommandRet runs the specified command and returns its result.
@@ -170,7 +216,7 @@ func (m *Mpv) CommandRet(cmd []string) (interface{}, error) {
 	}
 
 	var result C.mpv_node
-	err := newError(int(C.mpv_command_ret(m.handle, arr, &result)))
+	err := opError(int(C.mpv_command_ret(m.handle, arr, &result)), "command_ret", "", cmd)
 	if err != nil {
 		return nil, err
 	}
@@ -180,7 +226,17 @@ func (m *Mpv) CommandRet(cmd []string) (interface{}, error) {
 }
 
 // CommandAsync runs the command asynchronously.
+// replyUserdata must be below ReplyUserdataReserved, or ErrInvalidParameter is returned.
 func (m *Mpv) CommandAsync(replyUserdata uint64, cmd []string) error {
+	if err := checkReplyUserdata(replyUserdata); err != nil {
+		return err
+	}
+
+	return m.asyncCommand(replyUserdata, cmd)
+}
+
+// asyncCommand is CommandAsync for reply userdata in the reserved range.
+func (m *Mpv) asyncCommand(replyUserdata uint64, cmd []string) error {
 	arr := C.makeCharArray(C.int(len(cmd) + 1))
 	if arr == nil {
 		return ErrNomem
@@ -193,7 +249,7 @@ func (m *Mpv) CommandAsync(replyUserdata uint64, cmd []string) error {
 		C.setStringArray(arr, C.int(i), cs)
 	}
 
-	return newError(int(C.mpv_command_async(m.handle, C.uint64_t(replyUserdata), arr)))
+	return opError(int(C.mpv_command_async(m.handle, C.uint64_t(replyUserdata), arr)), "command_async", "", cmd)
 }
 
 // CommandNode runs a command given as a []any or map[string]any and returns its result.
@@ -202,7 +258,7 @@ func (m *Mpv) CommandNode(args interface{}) (interface{}, error) {
 	defer cleanup()
 
 	var result C.mpv_node
-	err := newError(int(C.mpv_command_node(m.handle, (*C.mpv_node)(cargs), &result)))
+	err := opError(int(C.mpv_command_node(m.handle, (*C.mpv_node)(cargs), &result)), "command_node", nodeCommandName(args), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -212,11 +268,21 @@ func (m *Mpv) CommandNode(args interface{}) (interface{}, error) {
 }
 
 // CommandNodeAsync runs a structured command asynchronously.
+// replyUserdata must be below ReplyUserdataReserved, or ErrInvalidParameter is returned.
 func (m *Mpv) CommandNodeAsync(replyUserdata uint64, args interface{}) error {
+	if err := checkReplyUserdata(replyUserdata); err != nil {
+		return err
+	}
+
+	return m.asyncCommandNode(replyUserdata, args)
+}
+
+// asyncCommandNode is CommandNodeAsync for reply userdata in the reserved range.
+func (m *Mpv) asyncCommandNode(replyUserdata uint64, args interface{}) error {
 	cargs, cleanup := goToNode(args)
 	defer cleanup()
 
-	return newError(int(C.mpv_command_node_async(m.handle, C.uint64_t(replyUserdata), (*C.mpv_node)(cargs))))
+	return opError(int(C.mpv_command_node_async(m.handle, C.uint64_t(replyUserdata), (*C.mpv_node)(cargs))), "command_node_async", nodeCommandName(args), nil)
 }
 
 // AbortAsyncCommand aborts an outstanding asynchronous command with the given reply userdata.
@@ -226,13 +292,17 @@ func (m *Mpv) AbortAsyncCommand(replyUserdata uint64) {
 
 // SetProperty sets the client property according to the given format.
 func (m *Mpv) SetProperty(name string, format Format, data interface{}) error {
+	if err := checkData(format, data); err != nil {
+		return err
+	}
+
 	cname := C.CString(name)
 	defer C.free(unsafe.Pointer(cname))
 
 	cdata, cleanup := convertData(format, data)
 	defer cleanup()
 
-	return newError(int(C.mpv_set_property(m.handle, cname, C.mpv_format(format), cdata)))
+	return opError(int(C.mpv_set_property(m.handle, cname, C.mpv_format(format), cdata)), "set_property", name, nil)
 }
 
 // SetPropertyString sets the property to the given string.
@@ -242,7 +312,7 @@ func (m *Mpv) SetPropertyString(name, value string) error {
 	cvalue := C.CString(value)
 	defer C.free(unsafe.Pointer(cvalue))
 
-	return newError(int(C.mpv_set_property_string(m.handle, cname, cvalue)))
+	return opError(int(C.mpv_set_property_string(m.handle, cname, cvalue)), "set_property_string", name, nil)
 }
 
 // DelProperty deletes the given property.
@@ -250,18 +320,32 @@ func (m *Mpv) DelProperty(name string) error {
 	cname := C.CString(name)
 	defer C.free(unsafe.Pointer(cname))
 
-	return newError(int(C.mpv_del_property(m.handle, cname)))
+	return opError(int(C.mpv_del_property(m.handle, cname)), "del_property", name, nil)
 }
 
 // SetPropertyAsync sets a property asynchronously.
+// replyUserdata must be below ReplyUserdataReserved, or ErrInvalidParameter is returned.
 func (m *Mpv) SetPropertyAsync(name string, replyUserdata uint64, format Format, data interface{}) error {
+	if err := checkReplyUserdata(replyUserdata); err != nil {
+		return err
+	}
+
+	return m.asyncSetProperty(name, replyUserdata, format, data)
+}
+
+// asyncSetProperty is SetPropertyAsync for reply userdata in the reserved range.
+func (m *Mpv) asyncSetProperty(name string, replyUserdata uint64, format Format, data interface{}) error {
+	if err := checkData(format, data); err != nil {
+		return err
+	}
+
 	cname := C.CString(name)
 	defer C.free(unsafe.Pointer(cname))
 
 	cdata, cleanup := convertData(format, data)
 	defer cleanup()
 
-	return newError(int(C.mpv_set_property_async(m.handle, C.uint64_t(replyUserdata), cname, C.mpv_format(format), cdata)))
+	return opError(int(C.mpv_set_property_async(m.handle, C.uint64_t(replyUserdata), cname, C.mpv_format(format), cdata)), "set_property_async", name, nil)
 }
 
 // GetProperty returns the value of the property according to the given format.
@@ -271,14 +355,14 @@ func (m *Mpv) GetProperty(name string, format Format) (interface{}, error) {
 
 	switch format {
 	case FormatNone:
-		err := newError(int(C.mpv_get_property(m.handle, n, C.mpv_format(format), nil)))
+		err := opError(int(C.mpv_get_property(m.handle, n, C.mpv_format(format), nil)), "get_property", name, nil)
 		if err != nil {
 			return nil, err
 		}
 		return nil, nil
 	case FormatString, FormatOsdString:
 		var result *C.char
-		err := newError(int(C.mpv_get_property(m.handle, n, C.mpv_format(format), unsafe.Pointer(&result))))
+		err := opError(int(C.mpv_get_property(m.handle, n, C.mpv_format(format), unsafe.Pointer(&result))), "get_property", name, nil)
 		if err != nil {
 			return nil, err
 		}
@@ -286,28 +370,28 @@ func (m *Mpv) GetProperty(name string, format Format) (interface{}, error) {
 		return C.GoString(result), nil
 	case FormatFlag:
 		var result C.int
-		err := newError(int(C.mpv_get_property(m.handle, n, C.mpv_format(format), unsafe.Pointer(&result))))
+		err := opError(int(C.mpv_get_property(m.handle, n, C.mpv_format(format), unsafe.Pointer(&result))), "get_property", name, nil)
 		if err != nil {
 			return nil, err
 		}
 		return result == 1, nil
 	case FormatInt64:
 		var result C.int64_t
-		err := newError(int(C.mpv_get_property(m.handle, n, C.mpv_format(format), unsafe.Pointer(&result))))
+		err := opError(int(C.mpv_get_property(m.handle, n, C.mpv_format(format), unsafe.Pointer(&result))), "get_property", name, nil)
 		if err != nil {
 			return nil, err
 		}
 		return int64(result), nil
 	case FormatDouble:
 		var result C.double
-		err := newError(int(C.mpv_get_property(m.handle, n, C.mpv_format(format), unsafe.Pointer(&result))))
+		err := opError(int(C.mpv_get_property(m.handle, n, C.mpv_format(format), unsafe.Pointer(&result))), "get_property", name, nil)
 		if err != nil {
 			return nil, err
 		}
 		return float64(result), nil
 	case FormatNode:
 		var result C.mpv_node
-		err := newError(int(C.mpv_get_property(m.handle, n, C.mpv_format(format), unsafe.Pointer(&result))))
+		err := opError(int(C.mpv_get_property(m.handle, n, C.mpv_format(format), unsafe.Pointer(&result))), "get_property", name, nil)
 		if err != nil {
 			return nil, err
 		}
@@ -319,7 +403,7 @@ func (m *Mpv) GetProperty(name string, format Format) (interface{}, error) {
 }
 
 // GetPropertyString returns the value of the property as a string.
-// If the property is empty, an empty string is returned.
+// If the property is empty or cannot be read, an empty string is returned, see PropertyString.
 func (m *Mpv) GetPropertyString(name string) string {
 	cname := C.CString(name)
 	defer C.free(unsafe.Pointer(cname))
@@ -335,6 +419,7 @@ func (m *Mpv) GetPropertyString(name string) string {
 }
 
 // GetPropertyOsdString returns the value of the property as a string formatted for on-screen display.
+// If the property cannot be read, an empty string is returned, see PropertyOsdString.
 func (m *Mpv) GetPropertyOsdString(name string) string {
 	cname := C.CString(name)
 	defer C.free(unsafe.Pointer(cname))
@@ -350,24 +435,54 @@ func (m *Mpv) GetPropertyOsdString(name string) string {
 }
 
 // GetPropertyAsync gets a property asynchronously.
+// replyUserdata must be below ReplyUserdataReserved, or ErrInvalidParameter is returned.
 func (m *Mpv) GetPropertyAsync(name string, replyUserdata uint64, format Format) error {
+	if err := checkReplyUserdata(replyUserdata); err != nil {
+		return err
+	}
+
+	return m.asyncGetProperty(name, replyUserdata, format)
+}
+
+// asyncGetProperty is GetPropertyAsync for reply userdata in the reserved range.
+func (m *Mpv) asyncGetProperty(name string, replyUserdata uint64, format Format) error {
 	cname := C.CString(name)
 	defer C.free(unsafe.Pointer(cname))
 
-	return newError(int(C.mpv_get_property_async(m.handle, C.uint64_t(replyUserdata), cname, C.mpv_format(format))))
+	return opError(int(C.mpv_get_property_async(m.handle, C.uint64_t(replyUserdata), cname, C.mpv_format(format))), "get_property_async", name, nil)
 }
 
 // ObserveProperty gets a notification whenever the given property changes.
+// replyUserdata must be below ReplyUserdataReserved, or ErrInvalidParameter is returned.
 func (m *Mpv) ObserveProperty(replyUserdata uint64, name string, format Format) error {
+	if err := checkReplyUserdata(replyUserdata); err != nil {
+		return err
+	}
+
+	return m.observeID(replyUserdata, name, format)
+}
+
+// observeID is ObserveProperty for reply userdata in the reserved range.
+func (m *Mpv) observeID(replyUserdata uint64, name string, format Format) error {
 	cname := C.CString(name)
 	defer C.free(unsafe.Pointer(cname))
 
-	return newError(int(C.mpv_observe_property(m.handle, C.uint64_t(replyUserdata), cname, C.mpv_format(format))))
+	return opError(int(C.mpv_observe_property(m.handle, C.uint64_t(replyUserdata), cname, C.mpv_format(format))), "observe_property", name, nil)
 }
 
 // UnobserveProperty will remove all observed properties for passed replyUserdata.
+// replyUserdata must be below ReplyUserdataReserved, or ErrInvalidParameter is returned.
 func (m *Mpv) UnobserveProperty(replyUserdata uint64) error {
-	return newError(int(C.mpv_unobserve_property(m.handle, C.uint64_t(replyUserdata))))
+	if err := checkReplyUserdata(replyUserdata); err != nil {
+		return err
+	}
+
+	return m.unobserveID(replyUserdata)
+}
+
+// unobserveID is UnobserveProperty for reply userdata in the reserved range.
+func (m *Mpv) unobserveID(replyUserdata uint64) error {
+	return opError(int(C.mpv_unobserve_property(m.handle, C.uint64_t(replyUserdata))), "unobserve_property", "", nil)
 }
 
 // RequestEvent enables or disables the given event.
@@ -377,7 +492,7 @@ func (m *Mpv) RequestEvent(event EventID, enable bool) error {
 		enable_ = 1
 	}
 
-	return newError(int(C.mpv_request_event(m.handle, C.mpv_event_id(event), enable_)))
+	return opError(int(C.mpv_request_event(m.handle, C.mpv_event_id(event), enable_)), "request_event", event.String(), nil)
 }
 
 // RequestLogMessages enables or disables receiving of log messages.
@@ -386,32 +501,47 @@ func (m *Mpv) RequestLogMessages(level string) error {
 	clevel := C.CString(level)
 	defer C.free(unsafe.Pointer(clevel))
 
-	return newError(int(C.mpv_request_log_messages(m.handle, clevel)))
+	return opError(int(C.mpv_request_log_messages(m.handle, clevel)), "request_log_messages", level, nil)
 }
 
 // HookAdd registers a hook handler for the named hook. Higher priority runs first.
+// replyUserdata must be below ReplyUserdataReserved, or ErrInvalidParameter is returned.
 func (m *Mpv) HookAdd(replyUserdata uint64, name string, priority int) error {
+	if err := checkReplyUserdata(replyUserdata); err != nil {
+		return err
+	}
+
+	return m.addHook(replyUserdata, name, priority)
+}
+
+// addHook is HookAdd for reply userdata in the reserved range.
+func (m *Mpv) addHook(replyUserdata uint64, name string, priority int) error {
 	cname := C.CString(name)
 	defer C.free(unsafe.Pointer(cname))
 
-	return newError(int(C.mpv_hook_add(m.handle, C.uint64_t(replyUserdata), cname, C.int(priority))))
+	return opError(int(C.mpv_hook_add(m.handle, C.uint64_t(replyUserdata), cname, C.int(priority))), "hook_add", name, nil)
 }
 
 // HookContinue continues the hook with the given ID from a hook event.
 func (m *Mpv) HookContinue(id uint64) error {
-	return newError(int(C.mpv_hook_continue(m.handle, C.uint64_t(id))))
+	return opError(int(C.mpv_hook_continue(m.handle, C.uint64_t(id))), "hook_continue", "", nil)
 }
 
 // WaitEvent calls mpv_wait_event and returns the result as an Event struct.
+// Client messages, log messages and events for internally allocated reply userdata are routed to their handlers first.
 func (m *Mpv) WaitEvent(timeout float64) *Event {
 	ev := C.mpv_wait_event(m.handle, C.double(timeout))
 
-	return &Event{
+	e := &Event{
 		EventID:       EventID(ev.event_id),
 		Data:          unsafe.Pointer(ev.data),
 		ReplyUserdata: uint64(ev.reply_userdata),
 		Error:         newError(int(ev.error)),
+		raw:           unsafe.Pointer(ev),
 	}
+	m.dispatch(e)
+
+	return e
 }
 
 // Wakeup interrupts the current mpv_wait_event() call.
@@ -429,6 +559,17 @@ func (m *Mpv) WaitAsyncRequests() {
 	C.mpv_wait_async_requests(m.handle)
 }
 
+// rawNode converts the C event with mpv_event_to_node.
+func (e *Event) rawNode() (any, error) {
+	var node C.mpv_node
+	if err := opError(int(C.mpv_event_to_node(&node, (*C.mpv_event)(e.raw))), "event_to_node", e.EventID.String(), nil); err != nil {
+		return nil, err
+	}
+	defer C.mpv_free_node_contents(&node)
+
+	return nodeToGo(unsafe.Pointer(&node)), nil
+}
+
 // convertData converts data for the given format into a pointer for SetOption/SetProperty,
 // and a cleanup function that must be called once the pointer is no longer needed.
 func convertData(format Format, data interface{}) (unsafe.Pointer, func()) {
@@ -20,6 +20,8 @@ var id func(handle uintptr) int64
 var initialize func(handle uintptr) int
 var terminateDestroy func(handle uintptr)
 var destroy func(handle uintptr)
+var createClient func(handle uintptr, name *byte) uintptr
+var createWeakClient func(handle uintptr, name *byte) uintptr
 var loadConfigFile func(handle uintptr, fileName string) int
 var timeUS func(handle uintptr) int64
 var timeNS func(handle uintptr) int64
@@ -52,6 +54,7 @@ var mpvFree func(data unsafe.Pointer)
 var commandNode func(handle uintptr, args, result unsafe.Pointer) int
 var commandNodeAsync func(handle uintptr, replyUserdata uint64, args unsafe.Pointer) int
 var freeNodeContents func(node unsafe.Pointer)
+var eventToNode func(dst, src unsafe.Pointer) int
 var memAlloc func(size uintptr) unsafe.Pointer
 var memFree func(p unsafe.Pointer)
 var setLocale func(category int, locale string) string
@@ -66,6 +69,8 @@ func init() {
 	purego.RegisterLibFunc(&initialize, libmpv, "mpv_initialize")
 	purego.RegisterLibFunc(&terminateDestroy, libmpv, "mpv_terminate_destroy")
 	purego.RegisterLibFunc(&destroy, libmpv, "mpv_destroy")
+	purego.RegisterLibFunc(&createClient, libmpv, "mpv_create_client")
+	purego.RegisterLibFunc(&createWeakClient, libmpv, "mpv_create_weak_client")
 	purego.RegisterLibFunc(&loadConfigFile, libmpv, "mpv_load_config_file")
 	purego.RegisterLibFunc(&timeUS, libmpv, "mpv_get_time_us")
 	purego.RegisterLibFunc(&timeNS, libmpv, "mpv_get_time_ns")
@@ -98,6 +103,7 @@ func init() {
 	purego.RegisterLibFunc(&commandNode, libmpv, "mpv_command_node")
 	purego.RegisterLibFunc(&commandNodeAsync, libmpv, "mpv_command_node_async")
 	purego.RegisterLibFunc(&freeNodeContents, libmpv, "mpv_free_node_contents")
+	purego.RegisterLibFunc(&eventToNode, libmpv, "mpv_event_to_node")
 
 	mem := memLibrary()
 	purego.RegisterLibFunc(&memAlloc, mem, "malloc")
@@ -117,11 +123,12 @@ func SetLocale(category int, locale string) string {
 // Mpv represents an mpv client.
 type Mpv struct {
 	handle uintptr
+	state  *clientState
 }
 
 // New creates a new mpv instance and an associated client API handle.
 func New() *Mpv {
-	return &Mpv{create()}
+	return &Mpv{handle: create(), state: newClientState()}
 }
 
 // APIVersion returns the client api version the mpv source has been compiled with.
@@ -141,22 +148,61 @@ func (m *Mpv) ID() int64 {
 
 // Initialize initializes an uninitialized mpv instance.
 func (m *Mpv) Initialize() error {
-	return newError(initialize(m.handle))
+	return opError(initialize(m.handle), "initialize", "", nil)
 }
 
 // TerminateDestroy terminates mpv and destroys the client.
+// All other clients of the same core, strong or weak, receive EventShutdown and must call Destroy.
 func (m *Mpv) TerminateDestroy() {
 	terminateDestroy(m.handle)
+	m.failFutures(errClientShutdown)
+	m.unregisterProtocols()
 }
 
 // Destroy disconnects and destroys this client handle without terminating mpv.
+// The core is terminated once the last strong client handle has been destroyed.
 func (m *Mpv) Destroy() {
 	destroy(m.handle)
+	m.failFutures(errClientShutdown)
+	m.unregisterProtocols()
+}
+
+// CreateClient creates a new client handle connected to the same mpv core, with its
+// own event queue and reply userdata space. An empty name lets mpv choose a unique one.
+// The new handle keeps the core alive until it is destroyed with Destroy or TerminateDestroy.
+func (m *Mpv) CreateClient(name string) (*Mpv, error) {
+	var cname *byte
+	if name != "" {
+		cname = cStr(name)
+	}
+
+	handle := createClient(m.handle, cname)
+	if handle == 0 {
+		return nil, ErrGeneric
+	}
+
+	return &Mpv{handle: handle, state: newClientState()}, nil
+}
+
+// CreateWeakClient is like CreateClient, but the handle does not keep the core alive.
+// When the last strong handle is destroyed, the weak client receives EventShutdown and must call Destroy.
+func (m *Mpv) CreateWeakClient(name string) (*Mpv, error) {
+	var cname *byte
+	if name != "" {
+		cname = cStr(name)
+	}
+
+	handle := createWeakClient(m.handle, cname)
+	if handle == 0 {
+		return nil, ErrGeneric
+	}
+
+	return &Mpv{handle: handle, state: newClientState()}, nil
 }
 
 // LoadConfigFile loads the given config file.
 func (m *Mpv) LoadConfigFile(fileName string) error {
-	return newError(loadConfigFile(m.handle, fileName))
+	return opError(loadConfigFile(m.handle, fileName), "load_config_file", fileName, nil)
 }
 
 // TimeUS returns the internal time in microseconds.
@@ -171,15 +217,19 @@ func (m *Mpv) TimeNS() int64 {
 
 // SetOption sets the given option according to the given format.
 func (m *Mpv) SetOption(name string, format Format, data interface{}) error {
+	if err := checkData(format, data); err != nil {
+		return err
+	}
+
 	cdata, cleanup := convertData(format, data)
 	defer cleanup()
 
-	return newError(setOption(m.handle, name, int(format), cdata))
+	return opError(setOption(m.handle, name, int(format), cdata), "set_option", name, nil)
 }
 
 // SetOptionString sets the option to the given string.
 func (m *Mpv) SetOptionString(name, value string) error {
-	return newError(setOptionString(m.handle, name, value))
+	return opError(setOptionString(m.handle, name, value), "set_option_string", name, nil)
 }
 
 // Command runs the specified command, returning an error if something goes wrong.
@@ -190,12 +240,12 @@ func (m *Mpv) Command(cmd []string) error {
 	}
 	cmds = append(cmds, nil)
 
-	return newError(command(m.handle, unsafe.SliceData(cmds)))
+	return opError(command(m.handle, unsafe.SliceData(cmds)), "command", "", cmd)
 }
 
 // CommandString runs the given command string, this string is parsed internally by mpv.
 func (m *Mpv) CommandString(cmd string) error {
-	return newError(commandString(m.handle, cmd))
+	return opError(commandString(m.handle, cmd), "command_string", "", []string{cmd})
 }
 
 // CommandRet runs the specified command and returns its result.
@@ -207,7 +257,7 @@ func (m *Mpv) CommandRet(cmd []string) (interface{}, error) {
 	cmds = append(cmds, nil)
 
 	var result cNode
-	err := newError(commandRet(m.handle, unsafe.SliceData(cmds), unsafe.Pointer(&result)))
+	err := opError(commandRet(m.handle, unsafe.SliceData(cmds), unsafe.Pointer(&result)), "command_ret", "", cmd)
 	if err != nil {
 		return nil, err
 	}
@@ -217,14 +267,24 @@ func (m *Mpv) CommandRet(cmd []string) (interface{}, error) {
 }
 
 // CommandAsync runs the command asynchronously.
+// replyUserdata must be below ReplyUserdataReserved, or ErrInvalidParameter is returned.
 func (m *Mpv) CommandAsync(replyUserdata uint64, cmd []string) error {
+	if err := checkReplyUserdata(replyUserdata); err != nil {
+		return err
+	}
+
+	return m.asyncCommand(replyUserdata, cmd)
+}
+
+// asyncCommand is CommandAsync for reply userdata in the reserved range.
+func (m *Mpv) asyncCommand(replyUserdata uint64, cmd []string) error {
 	cmds := make([]*byte, 0, len(cmd)+1)
 	for _, c := range cmd {
 		cmds = append(cmds, cStr(c))
 	}
 	cmds = append(cmds, nil)
 
-	return newError(commandAsync(m.handle, replyUserdata, unsafe.SliceData(cmds)))
+	return opError(commandAsync(m.handle, replyUserdata, unsafe.SliceData(cmds)), "command_async", "", cmd)
 }
 
 // CommandNode runs a command given as a []any or map[string]any and returns its result.
@@ -233,7 +293,7 @@ func (m *Mpv) CommandNode(args interface{}) (interface{}, error) {
 	defer cleanup()
 
 	var result cNode
-	err := newError(commandNode(m.handle, cargs, unsafe.Pointer(&result)))
+	err := opError(commandNode(m.handle, cargs, unsafe.Pointer(&result)), "command_node", nodeCommandName(args), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -243,11 +303,21 @@ func (m *Mpv) CommandNode(args interface{}) (interface{}, error) {
 }
 
 // CommandNodeAsync runs a structured command asynchronously.
+// replyUserdata must be below ReplyUserdataReserved, or ErrInvalidParameter is returned.
 func (m *Mpv) CommandNodeAsync(replyUserdata uint64, args interface{}) error {
+	if err := checkReplyUserdata(replyUserdata); err != nil {
+		return err
+	}
+
+	return m.asyncCommandNode(replyUserdata, args)
+}
+
+// asyncCommandNode is CommandNodeAsync for reply userdata in the reserved range.
+func (m *Mpv) asyncCommandNode(replyUserdata uint64, args interface{}) error {
 	cargs, cleanup := goToNode(args)
 	defer cleanup()
 
-	return newError(commandNodeAsync(m.handle, replyUserdata, cargs))
+	return opError(commandNodeAsync(m.handle, replyUserdata, cargs), "command_node_async", nodeCommandName(args), nil)
 }
 
 // AbortAsyncCommand aborts an outstanding asynchronous command with the given reply userdata.
@@ -257,42 +327,60 @@ func (m *Mpv) AbortAsyncCommand(replyUserdata uint64) {
 
 // SetProperty sets the client property according to the given format.
 func (m *Mpv) SetProperty(name string, format Format, data interface{}) error {
+	if err := checkData(format, data); err != nil {
+		return err
+	}
+
 	cdata, cleanup := convertData(format, data)
 	defer cleanup()
 
-	return newError(setProperty(m.handle, name, int(format), cdata))
+	return opError(setProperty(m.handle, name, int(format), cdata), "set_property", name, nil)
 }
 
 // SetPropertyString sets the property to the given string.
 func (m *Mpv) SetPropertyString(name, value string) error {
-	return newError(setPropertyString(m.handle, name, value))
+	return opError(setPropertyString(m.handle, name, value), "set_property_string", name, nil)
 }
 
 // DelProperty deletes the given property.
 func (m *Mpv) DelProperty(name string) error {
-	return newError(delProperty(m.handle, name))
+	return opError(delProperty(m.handle, name), "del_property", name, nil)
 }
 
 // SetPropertyAsync sets a property asynchronously.
+// replyUserdata must be below ReplyUserdataReserved, or ErrInvalidParameter is returned.
 func (m *Mpv) SetPropertyAsync(name string, replyUserdata uint64, format Format, data interface{}) error {
+	if err := checkReplyUserdata(replyUserdata); err != nil {
+		return err
+	}
+
+	return m.asyncSetProperty(name, replyUserdata, format, data)
+}
+
+// asyncSetProperty is SetPropertyAsync for reply userdata in the reserved range.
+func (m *Mpv) asyncSetProperty(name string, replyUserdata uint64, format Format, data interface{}) error {
+	if err := checkData(format, data); err != nil {
+		return err
+	}
+
 	cdata, cleanup := convertData(format, data)
 	defer cleanup()
 
-	return newError(setPropertyAsync(m.handle, replyUserdata, name, int(format), cdata))
+	return opError(setPropertyAsync(m.handle, replyUserdata, name, int(format), cdata), "set_property_async", name, nil)
 }
 
 // GetProperty returns the value of the property according to the given format.
 func (m *Mpv) GetProperty(name string, format Format) (interface{}, error) {
 	switch format {
 	case FormatNone:
-		err := newError(getProperty(m.handle, name, int(format), nil))
+		err := opError(getProperty(m.handle, name, int(format), nil), "get_property", name, nil)
 		if err != nil {
 			return nil, err
 		}
 		return nil, nil
 	case FormatString, FormatOsdString:
 		var result *byte
-		err := newError(getProperty(m.handle, name, int(format), unsafe.Pointer(&result)))
+		err := opError(getProperty(m.handle, name, int(format), unsafe.Pointer(&result)), "get_property", name, nil)
 		if err != nil {
 			return nil, err
 		}
@@ -300,28 +388,28 @@ func (m *Mpv) GetProperty(name string, format Format) (interface{}, error) {
 		return toStr(unsafe.Pointer(result)), nil
 	case FormatFlag:
 		var result int32
-		err := newError(getProperty(m.handle, name, int(format), unsafe.Pointer(&result)))
+		err := opError(getProperty(m.handle, name, int(format), unsafe.Pointer(&result)), "get_property", name, nil)
 		if err != nil {
 			return nil, err
 		}
 		return result == 1, nil
 	case FormatInt64:
 		var result int64
-		err := newError(getProperty(m.handle, name, int(format), unsafe.Pointer(&result)))
+		err := opError(getProperty(m.handle, name, int(format), unsafe.Pointer(&result)), "get_property", name, nil)
 		if err != nil {
 			return nil, err
 		}
 		return int64(result), nil
 	case FormatDouble:
 		var result float64
-		err := newError(getProperty(m.handle, name, int(format), unsafe.Pointer(&result)))
+		err := opError(getProperty(m.handle, name, int(format), unsafe.Pointer(&result)), "get_property", name, nil)
 		if err != nil {
 			return nil, err
 		}
 		return float64(result), nil
 	case FormatNode:
 		var result cNode
-		err := newError(getProperty(m.handle, name, int(format), unsafe.Pointer(&result)))
+		err := opError(getProperty(m.handle, name, int(format), unsafe.Pointer(&result)), "get_property", name, nil)
 		if err != nil {
 			return nil, err
 		}
@@ -333,7 +421,7 @@ func (m *Mpv) GetProperty(name string, format Format) (interface{}, error) {
 }
 
 // GetPropertyString returns the value of the property as a string.
-// If the property is empty, an empty string is returned.
+// If the property is empty or cannot be read, an empty string is returned, see PropertyString.
 func (m *Mpv) GetPropertyString(name string) string {
 	str := getPropertyString(m.handle, name)
 	if str == nil {
@@ -345,6 +433,7 @@ func (m *Mpv) GetPropertyString(name string) string {
 }
 
 // GetPropertyOsdString returns the value of the property as a string formatted for on-screen display.
+// If the property cannot be read, an empty string is returned, see PropertyOsdString.
 func (m *Mpv) GetPropertyOsdString(name string) string {
 	str := getPropertyOsdString(m.handle, name)
 	if str == nil {
@@ -356,51 +445,96 @@ func (m *Mpv) GetPropertyOsdString(name string) string {
 }
 
 // GetPropertyAsync gets a property asynchronously.
+// replyUserdata must be below ReplyUserdataReserved, or ErrInvalidParameter is returned.
 func (m *Mpv) GetPropertyAsync(name string, replyUserdata uint64, format Format) error {
-	return newError(getPropertyAsync(m.handle, replyUserdata, name, int(format)))
+	if err := checkReplyUserdata(replyUserdata); err != nil {
+		return err
+	}
+
+	return m.asyncGetProperty(name, replyUserdata, format)
+}
+
+// asyncGetProperty is GetPropertyAsync for reply userdata in the reserved range.
+func (m *Mpv) asyncGetProperty(name string, replyUserdata uint64, format Format) error {
+	return opError(getPropertyAsync(m.handle, replyUserdata, name, int(format)), "get_property_async", name, nil)
 }
 
 // ObserveProperty gets a notification whenever the given property changes.
+// replyUserdata must be below ReplyUserdataReserved, or ErrInvalidParameter is returned.
 func (m *Mpv) ObserveProperty(replyUserdata uint64, name string, format Format) error {
-	return newError(observeProperty(m.handle, replyUserdata, name, int(format)))
+	if err := checkReplyUserdata(replyUserdata); err != nil {
+		return err
+	}
+
+	return m.observeID(replyUserdata, name, format)
+}
+
+// observeID is ObserveProperty for reply userdata in the reserved range.
+func (m *Mpv) observeID(replyUserdata uint64, name string, format Format) error {
+	return opError(observeProperty(m.handle, replyUserdata, name, int(format)), "observe_property", name, nil)
 }
 
 // UnobserveProperty will remove all observed properties for passed replyUserdata.
+// replyUserdata must be below ReplyUserdataReserved, or ErrInvalidParameter is returned.
 func (m *Mpv) UnobserveProperty(replyUserdata uint64) error {
-	return newError(unobserveProperty(m.handle, replyUserdata))
+	if err := checkReplyUserdata(replyUserdata); err != nil {
+		return err
+	}
+
+	return m.unobserveID(replyUserdata)
+}
+
+// unobserveID is UnobserveProperty for reply userdata in the reserved range.
+func (m *Mpv) unobserveID(replyUserdata uint64) error {
+	return opError(unobserveProperty(m.handle, replyUserdata), "unobserve_property", "", nil)
 }
 
 // RequestEvent enables or disables the given event.
 func (m *Mpv) RequestEvent(event EventID, enable bool) error {
-	return newError(requestEvent(m.handle, int(event), enable))
+	return opError(requestEvent(m.handle, int(event), enable), "request_event", event.String(), nil)
 }
 
 // RequestLogMessages enables or disables receiving of log messages.
 // Valid log levels: no fatal error warn info v debug trace.
 func (m *Mpv) RequestLogMessages(level string) error {
-	return newError(requestLogMessages(m.handle, level))
+	return opError(requestLogMessages(m.handle, level), "request_log_messages", level, nil)
 }
 
 // HookAdd registers a hook handler for the named hook. Higher priority runs first.
+// replyUserdata must be below ReplyUserdataReserved, or ErrInvalidParameter is returned.
 func (m *Mpv) HookAdd(replyUserdata uint64, name string, priority int) error {
-	return newError(hookAdd(m.handle, replyUserdata, name, priority))
+	if err := checkReplyUserdata(replyUserdata); err != nil {
+		return err
+	}
+
+	return m.addHook(replyUserdata, name, priority)
+}
+
+// addHook is HookAdd for reply userdata in the reserved range.
+func (m *Mpv) addHook(replyUserdata uint64, name string, priority int) error {
+	return opError(hookAdd(m.handle, replyUserdata, name, priority), "hook_add", name, nil)
 }
 
 // HookContinue continues the hook with the given ID from a hook event.
 func (m *Mpv) HookContinue(id uint64) error {
-	return newError(hookContinue(m.handle, id))
+	return opError(hookContinue(m.handle, id), "hook_continue", "", nil)
 }
 
 // WaitEvent calls mpv_wait_event and returns the result as an Event struct.
+// Client messages, log messages and events for internally allocated reply userdata are routed to their handlers first.
 func (m *Mpv) WaitEvent(timeout float64) *Event {
 	ev := waitEvent(m.handle, timeout)
 
-	return &Event{
+	e := &Event{
 		EventID:       EventID(ev.EventID),
 		Error:         newError(int(ev.Error)),
 		ReplyUserdata: ev.ReplyUserdata,
 		Data:          ev.Data,
+		raw:           unsafe.Pointer(ev),
 	}
+	m.dispatch(e)
+
+	return e
 }
 
 // Wakeup interrupts the current WaitEvent() call.
@@ -418,6 +552,17 @@ func (m *Mpv) WaitAsyncRequests() {
 	waitAsyncRequests(m.handle)
 }
 
+// rawNode converts the C event with mpv_event_to_node.
+func (e *Event) rawNode() (any, error) {
+	var node cNode
+	if err := opError(eventToNode(unsafe.Pointer(&node), e.raw), "event_to_node", e.EventID.String(), nil); err != nil {
+		return nil, err
+	}
+	defer freeNodeContents(unsafe.Pointer(&node))
+
+	return nodeToGo(unsafe.Pointer(&node)), nil
+}
+
 // convertData converts data for the given format into a pointer for SetOption/SetProperty,
 // and a cleanup function that must be called once the pointer is no longer needed.
 func convertData(format Format, data interface{}) (unsafe.Pointer, func()) {
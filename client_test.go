@@ -52,7 +52,10 @@ loop:
 
 		switch e.EventID {
 		case mpv.EventPropertyChange:
-			prop := e.Property()
+			prop, err := e.Property()
+			if err != nil {
+				t.Fatalf("Property: %v", err)
+			}
 			value := prop.Data.(int)
 			fmt.Println("property:", prop.Name, value)
 		case mpv.EventFileLoaded:
@@ -62,18 +65,27 @@ loop:
 			}
 			fmt.Println("title:", p.(string))
 		case mpv.EventLogMsg:
-			msg := e.LogMessage()
-			fmt.Println("message:", msg.Text)
+			msg, err := e.LogMessage()
+			if err != nil {
+				t.Fatalf("LogMessage: %v", err)
+			}
+			fmt.Print("message: ", msg.Text)
 		case mpv.EventStart:
-			sf := e.StartFile()
+			sf, err := e.StartFile()
+			if err != nil {
+				t.Fatalf("StartFile: %v", err)
+			}
 			fmt.Println("start:", sf.EntryID)
 		case mpv.EventEnd:
-			ef := e.EndFile()
+			ef, err := e.EndFile()
+			if err != nil {
+				t.Fatalf("EndFile: %v", err)
+			}
 			fmt.Println("end:", ef.EntryID, ef.Reason)
 			if ef.Reason == mpv.EndFileEOF {
 				break loop
 			} else if ef.Reason == mpv.EndFileError {
-				t.Errorf("EventEnd: %v", err)
+				t.Errorf("EventEnd: %v", ef.Error)
 			}
 		case mpv.EventShutdown:
 			fmt.Println("shutdown:", e.EventID)
@@ -87,3 +99,66 @@ loop:
 		}
 	}
 }
+
+func TestCreateClient(t *testing.T) {
+	m := mpv.New()
+	defer m.TerminateDestroy()
+
+	_ = m.SetOptionString("vo", "null")
+	_ = m.SetOptionString("ao", "null")
+	if err := m.Initialize(); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+
+	c, err := m.CreateClient("second")
+	if err != nil {
+		t.Fatalf("CreateClient: %v", err)
+	}
+	defer c.Destroy()
+
+	w, err := m.CreateWeakClient("")
+	if err != nil {
+		t.Fatalf("CreateWeakClient: %v", err)
+	}
+	defer w.Destroy()
+
+	if c.Name() != "second" {
+		t.Errorf("Name = %q, want second", c.Name())
+	}
+	if w.Name() == "" || w.Name() == c.Name() {
+		t.Errorf("weak client name = %q, want a unique generated name", w.Name())
+	}
+	if c.ID() == m.ID() || w.ID() == m.ID() || w.ID() == c.ID() {
+		t.Errorf("client IDs are not unique: %d %d %d", m.ID(), c.ID(), w.ID())
+	}
+
+	// A targeted message only lands in the queue of the named client.
+	if err := m.Command([]string{"script-message-to", "second", "ping"}); err != nil {
+		t.Fatalf("script-message-to: %v", err)
+	}
+
+	got := false
+	for i := 0; i < 100; i++ {
+		e := c.WaitEvent(1)
+		if e.EventID == mpv.EventClientMessage {
+			got = true
+			break
+		}
+		if e.EventID == mpv.EventNone {
+			break
+		}
+	}
+	if !got {
+		t.Fatal("second client did not receive the client-message")
+	}
+
+	for {
+		e := m.WaitEvent(0)
+		if e.EventID == mpv.EventNone {
+			break
+		}
+		if e.EventID == mpv.EventClientMessage {
+			t.Fatal("main client received a message targeted at another client")
+		}
+	}
+}
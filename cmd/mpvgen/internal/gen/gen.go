@@ -0,0 +1,438 @@
+// Package gen generates the typed property accessors of package mpv from a Snapshot of
+// libmpv's property and option lists. It does not use libmpv itself, so it builds and is
+// tested anywhere; mpvgen reads the snapshot from libmpv or from a JSON file.
+package gen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// Spec selects a property. Properties that are not options need a Type, since option-info
+// knows nothing about them: "flag", "int64", "double", "string", "time" (seconds as
+// time.Duration) or "track" (a TrackID). Method overrides the generated method name.
+type Spec struct {
+	Name     string
+	Type     string
+	ReadOnly bool
+	Method   string
+}
+
+// Specs are the properties generated without -all.
+var Specs = []Spec{
+	// Playback.
+	{Name: "pause"},
+	{Name: "speed"},
+	{Name: "time-pos", Type: "time"},
+	{Name: "playback-time", Type: "time"},
+	{Name: "time-remaining", Type: "time", ReadOnly: true},
+	{Name: "duration", Type: "time", ReadOnly: true},
+	{Name: "percent-pos", Type: "double"},
+	{Name: "chapter", Type: "int64", Method: "CurrentChapter"},
+	{Name: "chapters", Type: "int64", ReadOnly: true, Method: "ChapterCount"},
+	{Name: "loop-file"},
+	{Name: "loop-playlist"},
+	{Name: "keep-open"},
+	{Name: "idle"},
+	{Name: "hr-seek"},
+	{Name: "image-display-duration"},
+	{Name: "gapless-audio"},
+
+	// State.
+	{Name: "idle-active", Type: "flag", ReadOnly: true},
+	{Name: "core-idle", Type: "flag", ReadOnly: true},
+	{Name: "eof-reached", Type: "flag", ReadOnly: true},
+	{Name: "seeking", Type: "flag", ReadOnly: true},
+	{Name: "paused-for-cache", Type: "flag", ReadOnly: true},
+	{Name: "cache-buffering-state", Type: "int64", ReadOnly: true},
+
+	// File.
+	{Name: "path", Type: "string", ReadOnly: true},
+	{Name: "filename", Type: "string", ReadOnly: true},
+	{Name: "media-title", Type: "string", ReadOnly: true},
+	{Name: "file-size", Type: "int64", ReadOnly: true},
+	{Name: "playlist-pos", Type: "int64"},
+	{Name: "playlist-count", Type: "int64", ReadOnly: true},
+
+	// Tracks.
+	{Name: "aid", Type: "track"},
+	{Name: "vid", Type: "track"},
+	{Name: "sid", Type: "track"},
+
+	// Audio.
+	{Name: "volume"},
+	{Name: "volume-max"},
+	{Name: "mute"},
+	{Name: "audio-delay"},
+	{Name: "audio-device"},
+	{Name: "audio-pitch-correction"},
+	{Name: "replaygain"},
+
+	// Video.
+	{Name: "vo"},
+	{Name: "hwdec"},
+	{Name: "hwdec-current", Type: "string", ReadOnly: true},
+	{Name: "fullscreen"},
+	{Name: "ontop"},
+	{Name: "border"},
+	{Name: "force-window"},
+	{Name: "video-sync"},
+	{Name: "interpolation"},
+	{Name: "deinterlace"},
+	{Name: "video-zoom"},
+	{Name: "video-rotate"},
+	{Name: "panscan"},
+	{Name: "brightness"},
+	{Name: "contrast"},
+	{Name: "saturation"},
+	{Name: "gamma"},
+	{Name: "hue"},
+	{Name: "width", Type: "int64", ReadOnly: true},
+	{Name: "height", Type: "int64", ReadOnly: true},
+	{Name: "container-fps", Type: "double", ReadOnly: true},
+	{Name: "estimated-vf-fps", Type: "double", ReadOnly: true},
+	{Name: "display-fps", Type: "double", ReadOnly: true},
+	{Name: "estimated-frame-count", Type: "int64", ReadOnly: true},
+	{Name: "estimated-frame-number", Type: "int64", ReadOnly: true},
+	{Name: "avsync", Type: "double", ReadOnly: true},
+
+	// Subtitles.
+	{Name: "sub-visibility"},
+	{Name: "sub-delay"},
+	{Name: "sub-scale"},
+	{Name: "sub-pos"},
+	{Name: "sub-auto"},
+
+	// Misc.
+	{Name: "title"},
+	{Name: "osd-level"},
+	{Name: "cursor-autohide"},
+	{Name: "cache"},
+	{Name: "demuxer-readahead-secs"},
+	{Name: "ytdl"},
+	{Name: "screenshot-format"},
+	{Name: "screenshot-dir"},
+	{Name: "mpv-version", Type: "string", ReadOnly: true},
+	{Name: "ffmpeg-version", Type: "string", ReadOnly: true},
+}
+
+// initialisms are kept upper case in method names.
+var initialisms = map[string]string{
+	"aid": "AID", "vid": "VID", "sid": "SID", "id": "ID", "osd": "OSD", "fps": "FPS",
+	"vo": "VO", "ao": "AO", "hwdec": "HWDec", "hr": "HR", "icc": "ICC", "url": "URL",
+	"ytdl": "YTDL", "mpv": "Mpv", "ffmpeg": "FFmpeg", "avsync": "AVSync",
+}
+
+// OptionInfo is the value of option-info/<name>.
+type OptionInfo struct {
+	Name         string   `mpv:"name" json:"name"`
+	Type         string   `mpv:"type" json:"type"`
+	DefaultValue any      `mpv:"default-value" json:"default-value,omitempty"`
+	Min          *float64 `mpv:"min" json:"min,omitempty"`
+	Max          *float64 `mpv:"max" json:"max,omitempty"`
+	Choices      []string `mpv:"choices" json:"choices,omitempty"`
+}
+
+// Snapshot is everything mpvgen reads from libmpv, the format of its -dump and -from files.
+type Snapshot struct {
+	Version    string                 `json:"version"`
+	Properties []string               `json:"properties"`
+	Options    map[string]*OptionInfo `json:"options"`
+}
+
+// ReadSnapshot reads a snapshot saved as JSON.
+func ReadSnapshot(name string) (*Snapshot, error) {
+	b, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	snap := &Snapshot{}
+	if err := json.Unmarshal(b, snap); err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+
+	return snap, nil
+}
+
+// ExistingMethods returns the names of the hand-written methods and declarations of the package in dir.
+func ExistingMethods(dir, generated string) (map[string]bool, error) {
+	fset := token.NewFileSet()
+	names := map[string]bool{}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, file := range files {
+		if filepath.Base(file) == generated || strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, file, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		for _, decl := range f.Decls {
+			switch d := decl.(type) {
+			case *ast.FuncDecl:
+				names[d.Name.Name] = true
+			case *ast.GenDecl:
+				for _, s := range d.Specs {
+					switch s := s.(type) {
+					case *ast.TypeSpec:
+						names[s.Name.Name] = true
+					case *ast.ValueSpec:
+						for _, n := range s.Names {
+							names[n.Name] = true
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return names, nil
+}
+
+// AllSpecs returns a Spec for every property that is also an option with a known type.
+func AllSpecs(snap *Snapshot, existing map[string]bool) []Spec {
+	var list []Spec
+	for _, name := range snap.Properties {
+		info := snap.Options[name]
+		if info == nil || kindOf(info) == "" || existing[camel(name)] || existing["Set"+camel(name)] {
+			continue
+		}
+		list = append(list, Spec{Name: name})
+	}
+
+	return list
+}
+
+// accessor is the template data for one property.
+type accessor struct {
+	Name     string
+	Method   string
+	Kind     string
+	GoType   string
+	ReadOnly bool
+	Doc      []string
+	Choices  []choice
+}
+
+type choice struct {
+	Const string
+	Value string
+}
+
+// kindOf maps an option type to the kind of accessor, or "" if it has no typed accessor.
+func kindOf(info *OptionInfo) string {
+	switch info.Type {
+	case "Flag":
+		return "flag"
+	case "Integer", "Integer64":
+		return "int64"
+	case "Double", "Float":
+		return "double"
+	case "Choice":
+		return "choice"
+	case "String", "Object settings list", "String list", "Key/value list", "ByteSize", "Aspect":
+		return "string"
+	}
+
+	return ""
+}
+
+var goTypes = map[string]string{
+	"flag":   "bool",
+	"int64":  "int64",
+	"double": "float64",
+	"string": "string",
+	"time":   "time.Duration",
+	"track":  "TrackID",
+}
+
+// Generate returns the formatted source of the accessors for list. Properties missing from
+// the snapshot, without a type or whose method name is in existing are skipped.
+func Generate(snap *Snapshot, list []Spec, existing map[string]bool) ([]byte, error) {
+	available := map[string]bool{}
+	for _, name := range snap.Properties {
+		available[name] = true
+	}
+
+	var accessors []accessor
+	for _, s := range list {
+		if !available[s.Name] {
+			log.Printf("skipping %s: not in property-list", s.Name)
+			continue
+		}
+
+		a := accessor{Name: s.Name, Method: s.Method, Kind: s.Type, ReadOnly: s.ReadOnly}
+		if a.Method == "" {
+			a.Method = camel(s.Name)
+		}
+
+		info := snap.Options[s.Name]
+		if a.Kind == "" && info != nil {
+			a.Kind = kindOf(info)
+		}
+		if a.Kind == "" {
+			log.Printf("skipping %s: no type", s.Name)
+			continue
+		}
+		if existing[a.Method] || (!a.ReadOnly && existing["Set"+a.Method]) {
+			log.Printf("skipping %s: %s is already declared", s.Name, a.Method)
+			continue
+		}
+
+		a.GoType = goTypes[a.Kind]
+		if a.Kind == "choice" {
+			a.GoType = a.Method + "Choice"
+			for _, c := range info.Choices {
+				a.Choices = append(a.Choices, choice{Const: a.Method + camel(c), Value: c})
+			}
+		}
+		if info != nil {
+			a.Doc = docLines(info, a.Kind)
+		}
+
+		accessors = append(accessors, a)
+	}
+
+	slices.SortFunc(accessors, func(a, b accessor) int { return strings.Compare(a.Method, b.Method) })
+
+	var buf bytes.Buffer
+	err := tmpl.Execute(&buf, map[string]any{
+		"Version":   snap.Version,
+		"Accessors": accessors,
+		"Time":      slices.ContainsFunc(accessors, func(a accessor) bool { return a.Kind == "time" }),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return format.Source(buf.Bytes())
+}
+
+// docLines describes the default value and range of an option with the given accessor kind.
+func docLines(info *OptionInfo, kind string) []string {
+	var lines []string
+
+	switch v := info.DefaultValue.(type) {
+	case bool:
+		lines = append(lines, "Default: "+map[bool]string{true: "yes", false: "no"}[v]+".")
+	case float64:
+		lines = append(lines, "Default: "+number(v)+".")
+	case int64:
+		lines = append(lines, "Default: "+strconv.FormatInt(v, 10)+".")
+	case string:
+		if v != "" {
+			lines = append(lines, "Default: "+strconv.Quote(v)+".")
+		}
+	}
+
+	if info.Min != nil && info.Max != nil {
+		lines = append(lines, "Range: "+number(*info.Min)+" to "+number(*info.Max)+".")
+	} else if info.Min != nil {
+		lines = append(lines, "Minimum: "+number(*info.Min)+".")
+	} else if info.Max != nil {
+		lines = append(lines, "Maximum: "+number(*info.Max)+".")
+	}
+
+	if len(info.Choices) > 0 && kind == "choice" && info.Min != nil {
+		lines = append(lines, "Besides the choices, the value can be a number in the range.")
+	}
+
+	return lines
+}
+
+func number(f float64) string {
+	if f == math.Trunc(f) && math.Abs(f) < 1e15 {
+		return strconv.FormatInt(int64(f), 10)
+	}
+
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+// camel converts a property name or choice to a Go identifier, e.g. "sub-delay" to "SubDelay".
+func camel(s string) string {
+	var sb strings.Builder
+	for _, part := range strings.FieldsFunc(s, func(r rune) bool { return r == '-' || r == '_' || r == '/' || r == '.' }) {
+		if v, ok := initialisms[part]; ok {
+			sb.WriteString(v)
+			continue
+		}
+		sb.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+
+	return sb.String()
+}
+
+var tmpl = template.Must(template.New("").Parse(`// Code generated by mpvgen from {{.Version}}; DO NOT EDIT.
+
+package mpv
+{{if .Time}}
+import "time"
+{{end}}
+{{range .Accessors}}{{if .Choices}}
+// {{.GoType}} is a value of the {{.Name}} option.
+type {{.GoType}} string
+
+// {{.GoType}} values.
+const (
+{{- $t := .GoType}}{{range .Choices}}
+	{{.Const}} {{$t}} = {{printf "%q" .Value}}
+{{- end}}
+)
+{{end}}
+// {{.Method}} returns the {{.Name}} property.
+{{- if .Doc}}
+//{{range .Doc}}
+// {{.}}{{end}}{{end}}
+func (m *Mpv) {{.Method}}() ({{.GoType}}, error) {
+{{- if eq .Kind "choice"}}
+	v, err := Get[string](m, "{{.Name}}")
+
+	return {{.GoType}}(v), err
+{{- else if eq .Kind "time"}}
+	v, err := Get[float64](m, "{{.Name}}")
+
+	return time.Duration(v * float64(time.Second)), err
+{{- else if eq .Kind "track"}}
+	v, err := Get[string](m, "{{.Name}}")
+	if err != nil {
+		return 0, err
+	}
+
+	return parseTrackID(v)
+{{- else}}
+	return Get[{{.GoType}}](m, "{{.Name}}")
+{{- end}}
+}
+{{if not .ReadOnly}}
+// Set{{.Method}} sets the {{.Name}} property.
+func (m *Mpv) Set{{.Method}}(v {{.GoType}}) error {
+{{- if eq .Kind "choice"}}
+	return Set(m, "{{.Name}}", string(v))
+{{- else if eq .Kind "time"}}
+	return Set(m, "{{.Name}}", v.Seconds())
+{{- else if eq .Kind "track"}}
+	return Set(m, "{{.Name}}", v.String())
+{{- else}}
+	return Set(m, "{{.Name}}", v)
+{{- end}}
+}
+{{end}}{{end}}`))
@@ -0,0 +1,96 @@
+package gen
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestCamel(t *testing.T) {
+	for in, want := range map[string]string{
+		"volume":            "Volume",
+		"sub-delay":         "SubDelay",
+		"hwdec-current":     "HWDecCurrent",
+		"estimated-vf-fps":  "EstimatedVfFPS",
+		"display-resample":  "DisplayResample",
+		"option-info/speed": "OptionInfoSpeed",
+	} {
+		if got := camel(in); got != want {
+			t.Errorf("camel(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	minVol, maxVol := -1.0, 1000.0
+	snap := &Snapshot{
+		Version:    "mpv test",
+		Properties: []string{"volume", "keep-open", "duration", "chapter", "aid"},
+		Options: map[string]*OptionInfo{
+			"volume":    {Name: "volume", Type: "Float", DefaultValue: 100.0, Min: &minVol, Max: &maxVol},
+			"keep-open": {Name: "keep-open", Type: "Choice", DefaultValue: "no", Choices: []string{"no", "yes", "always"}},
+			"aid":       {Name: "aid", Type: "Choice", DefaultValue: "auto", Choices: []string{"no", "auto"}, Min: new(float64)},
+		},
+	}
+	list := []Spec{
+		{Name: "volume"},
+		{Name: "keep-open"},
+		{Name: "duration", Type: "time", ReadOnly: true},
+		{Name: "chapter", Type: "int64"},
+		{Name: "aid", Type: "track"},
+		{Name: "missing", Type: "int64"},
+	}
+
+	src, err := Generate(snap, list, map[string]bool{"Chapter": true})
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	out := string(src)
+
+	for _, s := range []string{
+		"// Code generated by mpvgen from mpv test; DO NOT EDIT.",
+		"func (m *Mpv) Volume() (float64, error) {",
+		"func (m *Mpv) SetVolume(v float64) error {",
+		"// Default: 100.\n// Range: -1 to 1000.",
+		`KeepOpenAlways KeepOpenChoice = "always"`,
+		"func (m *Mpv) Duration() (time.Duration, error) {",
+		"func (m *Mpv) AID() (TrackID, error) {",
+		"func (m *Mpv) SetAID(v TrackID) error {",
+	} {
+		if !strings.Contains(out, s) {
+			t.Errorf("output does not contain %q:\n%s", s, out)
+		}
+	}
+	for _, s := range []string{"SetDuration", "Chapter()", "Missing", "AIDChoice"} {
+		if strings.Contains(out, s) {
+			t.Errorf("output contains %q", s)
+		}
+	}
+}
+
+// TestGenerateCheckedIn checks that the checked in snapshot reproduces properties_gen.go.
+func TestGenerateCheckedIn(t *testing.T) {
+	const root = "../../../.."
+
+	snap, err := ReadSnapshot("../../testdata/snapshot.json")
+	if err != nil {
+		t.Fatalf("ReadSnapshot: %v", err)
+	}
+	existing, err := ExistingMethods(root, "properties_gen.go")
+	if err != nil {
+		t.Fatalf("ExistingMethods: %v", err)
+	}
+
+	src, err := Generate(snap, Specs, existing)
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	want, err := os.ReadFile(root + "/properties_gen.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(src, want) {
+		t.Error("properties_gen.go is not the output for testdata/snapshot.json, run go generate")
+	}
+}
@@ -0,0 +1,74 @@
+// Command mpvgen generates the typed property accessors of package mpv.
+//
+// It starts a headless libmpv, reads property-list, options and option-info/<name>, and writes
+// a getter and, for writable properties, a setter for every property in gen.Specs, with
+// enum types for choice options and the default and range in the doc comment. With -all it
+// generates accessors for every property that is also an option, skipping names that collide
+// with hand-written methods.
+//
+// The data read from libmpv can be saved with -dump and used again with -from. The snapshot
+// properties_gen.go is generated from is checked in as testdata/snapshot.json, so go generate
+// in the package directory needs no libmpv; building with the nolibmpv tag leaves libmpv out
+// entirely. When mpv is updated, refresh the snapshot from the installed libmpv first:
+//
+//	go run ./cmd/mpvgen -dump cmd/mpvgen/testdata/snapshot.json
+//	go generate
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/gen2brain/go-mpv/cmd/mpvgen/internal/gen"
+)
+
+func main() {
+	out := flag.String("o", "properties_gen.go", "output file")
+	all := flag.Bool("all", false, "generate accessors for all properties that are options")
+	dump := flag.String("dump", "", "write the data read from libmpv to this JSON file")
+	from := flag.String("from", "", "read the data from this JSON file instead of libmpv")
+	flag.Parse()
+
+	var snap *gen.Snapshot
+	var err error
+	if *from != "" {
+		snap, err = gen.ReadSnapshot(*from)
+	} else {
+		snap, err = query()
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if *dump != "" {
+		b, err := json.MarshalIndent(snap, "", "\t")
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := os.WriteFile(*dump, append(b, '\n'), 0o644); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	existing, err := gen.ExistingMethods(filepath.Dir(*out), filepath.Base(*out))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	list := gen.Specs
+	if *all {
+		list = gen.AllSpecs(snap, existing)
+	}
+
+	src, err := gen.Generate(snap, list, existing)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := os.WriteFile(*out, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
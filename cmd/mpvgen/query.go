@@ -0,0 +1,63 @@
+//go:build !nolibmpv
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/gen2brain/go-mpv"
+	"github.com/gen2brain/go-mpv/cmd/mpvgen/internal/gen"
+)
+
+// query starts a headless mpv and reads the property and option lists.
+func query() (*gen.Snapshot, error) {
+	m := mpv.New()
+	defer m.TerminateDestroy()
+
+	for _, opt := range [][2]string{{"vo", "null"}, {"ao", "null"}, {"config", "no"}, {"load-scripts", "no"}} {
+		if err := m.SetOptionString(opt[0], opt[1]); err != nil {
+			return nil, err
+		}
+	}
+	if err := m.Initialize(); err != nil {
+		return nil, err
+	}
+
+	snap := &gen.Snapshot{Options: map[string]*gen.OptionInfo{}}
+
+	var err error
+	if snap.Version, err = m.PropertyString("mpv-version"); err != nil {
+		return nil, err
+	}
+
+	v, err := m.GetProperty("property-list", mpv.FormatNode)
+	if err != nil {
+		return nil, err
+	}
+	if err := mpv.DecodeNode(v, &snap.Properties); err != nil {
+		return nil, err
+	}
+
+	v, err = m.GetProperty("options", mpv.FormatNode)
+	if err != nil {
+		return nil, err
+	}
+	var options []string
+	if err := mpv.DecodeNode(v, &options); err != nil {
+		return nil, err
+	}
+
+	for _, name := range options {
+		v, err := m.GetProperty("option-info/"+name, mpv.FormatNode)
+		if err != nil {
+			return nil, fmt.Errorf("option-info/%s: %w", name, err)
+		}
+		info := &gen.OptionInfo{}
+		if err := mpv.DecodeNode(v, info); err != nil {
+			return nil, fmt.Errorf("option-info/%s: %w", name, err)
+		}
+		snap.Options[name] = info
+	}
+
+	return snap, nil
+}
@@ -0,0 +1,14 @@
+//go:build nolibmpv
+
+package main
+
+import (
+	"errors"
+
+	"github.com/gen2brain/go-mpv/cmd/mpvgen/internal/gen"
+)
+
+// query fails, mpvgen was built without libmpv and can only read a snapshot.
+func query() (*gen.Snapshot, error) {
+	return nil, errors.New("mpvgen: built with the nolibmpv tag, use -from")
+}
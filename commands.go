@@ -0,0 +1,235 @@
+package mpv
+
+import (
+	"fmt"
+	"math/bits"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// SeekFlags selects how the seek target is interpreted, combine one mode with SeekKeyframes or SeekExact.
+type SeekFlags uint8
+
+// Seek flags; without a mode the target is relative, without a precision mpv uses the hr-seek option.
+const (
+	SeekRelative SeekFlags = 1 << iota
+	SeekAbsolute
+	SeekRelativePercent
+	SeekAbsolutePercent
+	SeekKeyframes
+	SeekExact
+)
+
+const (
+	seekModes     = SeekRelative | SeekAbsolute | SeekRelativePercent | SeekAbsolutePercent
+	seekPrecision = SeekKeyframes | SeekExact
+)
+
+var seekFlagNames = []string{"relative", "absolute", "relative-percent", "absolute-percent", "keyframes", "exact"}
+
+// String returns the flags in mpv's syntax, e.g. "absolute+exact".
+func (f SeekFlags) String() string {
+	var names []string
+	for i, name := range seekFlagNames {
+		if f&(1<<i) != 0 {
+			names = append(names, name)
+		}
+	}
+
+	return strings.Join(names, "+")
+}
+
+// LoadMode selects where LoadFile puts the file in the playlist.
+type LoadMode string
+
+// Load modes.
+const (
+	LoadReplace        LoadMode = "replace"
+	LoadAppend         LoadMode = "append"
+	LoadAppendPlay     LoadMode = "append-play"
+	LoadInsertNext     LoadMode = "insert-next"
+	LoadInsertNextPlay LoadMode = "insert-next-play"
+	LoadInsertAt       LoadMode = "insert-at"
+	LoadInsertAtPlay   LoadMode = "insert-at-play"
+)
+
+// ScreenshotMode selects what a screenshot contains.
+type ScreenshotMode string
+
+// Screenshot modes.
+const (
+	// ScreenshotSubtitles is the video with subtitles, the default.
+	ScreenshotSubtitles ScreenshotMode = "subtitles"
+	// ScreenshotVideo is the video only.
+	ScreenshotVideo ScreenshotMode = "video"
+	// ScreenshotWindow is the scaled window contents, including OSD.
+	ScreenshotWindow ScreenshotMode = "window"
+)
+
+// TrackMode selects what SubAdd and AudioAdd do with the added track.
+type TrackMode string
+
+// Track modes.
+const (
+	// TrackSelect selects the track immediately, the default.
+	TrackSelect TrackMode = "select"
+	// TrackAuto adds the track without selecting it, unless mpv's track selection picks it.
+	TrackAuto TrackMode = "auto"
+	// TrackCached selects an already added track with the same file name instead of adding it again.
+	TrackCached TrackMode = "cached"
+)
+
+// command runs the command as a node array, so the arguments are passed as is.
+func (m *Mpv) command(args ...any) error {
+	_, err := m.CommandNode(args)
+
+	return err
+}
+
+// Seek seeks to target, in seconds or percent depending on flags.
+func (m *Mpv) Seek(target float64, flags SeekFlags) error {
+	if bits.OnesCount8(uint8(flags&seekModes)) > 1 || flags&seekPrecision == seekPrecision {
+		return fmt.Errorf("%w: conflicting seek flags %s", ErrInvalidParameter, flags)
+	}
+
+	args := []any{"seek", strconv.FormatFloat(target, 'f', -1, 64)}
+	if flags != 0 {
+		args = append(args, flags.String())
+	}
+
+	return m.command(args...)
+}
+
+// LoadFile loads url according to mode. index is the playlist position for LoadInsertAt
+// and LoadInsertAtPlay and ignored otherwise. options are set as file-local options while
+// the file plays, e.g. {"start": "30", "pause": "yes"}. The insert-at modes need mpv 0.38
+// or later.
+func (m *Mpv) LoadFile(url string, mode LoadMode, index int, options map[string]string) error {
+	if mode == "" {
+		mode = LoadReplace
+	}
+
+	// Named arguments, since the positional index was inserted before options in mpv 0.38.
+	cmd := map[string]any{"name": "loadfile", "url": url, "flags": string(mode)}
+	if mode == LoadInsertAt || mode == LoadInsertAtPlay {
+		cmd["index"] = int64(index)
+	}
+	if len(options) > 0 {
+		cmd["options"] = keyValueList(options)
+	}
+
+	_, err := m.CommandNode(cmd)
+
+	return err
+}
+
+// keyValueList formats options as a key-value list, quoting the values with mpv's
+// %n% syntax so they may contain commas and equal signs.
+func keyValueList(options map[string]string) string {
+	keys := make([]string, 0, len(options))
+	for k := range options {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var sb strings.Builder
+	for i, k := range keys {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		v := options[k]
+		sb.WriteString(k + "=%" + strconv.Itoa(len(v)) + "%" + v)
+	}
+
+	return sb.String()
+}
+
+// PlaylistNext goes to the next playlist entry. Without force, nothing happens at the end of the playlist.
+func (m *Mpv) PlaylistNext(force bool) error {
+	return m.command("playlist-next", forceFlag(force))
+}
+
+// PlaylistPrev goes to the previous playlist entry. Without force, nothing happens at the start of the playlist.
+func (m *Mpv) PlaylistPrev(force bool) error {
+	return m.command("playlist-prev", forceFlag(force))
+}
+
+func forceFlag(force bool) string {
+	if force {
+		return "force"
+	}
+
+	return "weak"
+}
+
+// SaveScreenshot saves a screenshot to the screenshot directory, named by the screenshot-template
+// option. See Screenshot for taking one in memory.
+func (m *Mpv) SaveScreenshot(mode ScreenshotMode) error {
+	if mode == "" {
+		mode = ScreenshotSubtitles
+	}
+
+	return m.command("screenshot", string(mode))
+}
+
+// SubAdd adds an external subtitle file. title and lang are optional.
+func (m *Mpv) SubAdd(url string, mode TrackMode, title, lang string) error {
+	return m.trackAdd("sub-add", url, mode, title, lang)
+}
+
+// AudioAdd adds an external audio file. title and lang are optional.
+func (m *Mpv) AudioAdd(url string, mode TrackMode, title, lang string) error {
+	return m.trackAdd("audio-add", url, mode, title, lang)
+}
+
+func (m *Mpv) trackAdd(cmd, url string, mode TrackMode, title, lang string) error {
+	if mode == "" {
+		mode = TrackSelect
+	}
+
+	args := []any{cmd, url, string(mode), title, lang}
+	for len(args) > 3 && args[len(args)-1] == "" {
+		args = args[:len(args)-1]
+	}
+
+	return m.command(args...)
+}
+
+// CycleValues sets the property to the value after its current one in values, wrapping around.
+func (m *Mpv) CycleValues(name string, values ...string) error {
+	if len(values) == 0 {
+		return fmt.Errorf("%w: cycle-values needs at least one value", ErrInvalidParameter)
+	}
+
+	args := []any{"cycle-values", name}
+	for _, v := range values {
+		args = append(args, v)
+	}
+
+	return m.command(args...)
+}
+
+// FrameStep shows the next video frame and pauses.
+func (m *Mpv) FrameStep() error {
+	return m.command("frame-step")
+}
+
+// FrameBackStep shows the previous video frame and pauses. This is slow, it needs an exact seek.
+func (m *Mpv) FrameBackStep() error {
+	return m.command("frame-back-step")
+}
+
+// Stop stops playback and clears the playlist, unless keepPlaylist is set.
+func (m *Mpv) Stop(keepPlaylist bool) error {
+	if keepPlaylist {
+		return m.command("stop", "keep-playlist")
+	}
+
+	return m.command("stop")
+}
+
+// Quit exits the player with the given exit code, which shuts down all clients.
+func (m *Mpv) Quit(code int) error {
+	return m.command("quit", strconv.Itoa(code))
+}
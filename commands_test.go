@@ -0,0 +1,91 @@
+package mpv
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSeekFlags(t *testing.T) {
+	if got := (SeekAbsolute | SeekExact).String(); got != "absolute+exact" {
+		t.Errorf("String = %q, want absolute+exact", got)
+	}
+	if got := SeekRelativePercent.String(); got != "relative-percent" {
+		t.Errorf("String = %q, want relative-percent", got)
+	}
+	if got := keyValueList(map[string]string{"start": "5", "vf": "crop=10:10,scale=20:20"}); got != "start=%1%5,vf=%22%crop=10:10,scale=20:20" {
+		t.Errorf("keyValueList = %q", got)
+	}
+}
+
+func TestCommands(t *testing.T) {
+	m := newHeadless(t)
+
+	if err := m.LoadFile("testdata/test.mpg", LoadReplace, 0, map[string]string{"pause": "yes"}); err != nil {
+		t.Fatalf("LoadFile: %v", err)
+	}
+	waitFileLoaded(t, m)
+
+	if v, err := m.Pause(); err != nil || !v {
+		t.Errorf("pause = %v, %v, want the file-local option", v, err)
+	}
+
+	if err := m.LoadFile("testdata/test.mpg", LoadAppend, 0, nil); err != nil {
+		t.Fatalf("LoadFile append: %v", err)
+	}
+	if n, err := m.PlaylistCount(); err != nil || n != 2 {
+		t.Errorf("playlist-count = %d, %v, want 2", n, err)
+	}
+
+	if err := m.Seek(1, SeekAbsolute|SeekExact); err != nil {
+		t.Errorf("Seek: %v", err)
+	}
+	if err := m.Seek(1, SeekAbsolute|SeekRelative); !errors.Is(err, ErrInvalidParameter) {
+		t.Errorf("Seek with two modes: err = %v, want ErrInvalidParameter", err)
+	}
+	if err := m.FrameStep(); err != nil {
+		t.Errorf("FrameStep: %v", err)
+	}
+	if err := m.CycleValues("video-rotate", "90", "180"); err != nil {
+		t.Errorf("CycleValues: %v", err)
+	}
+	if err := m.PlaylistNext(false); err != nil {
+		t.Errorf("PlaylistNext: %v", err)
+	}
+	if err := m.PlaylistPrev(true); err != nil {
+		t.Errorf("PlaylistPrev: %v", err)
+	}
+	if err := m.SubAdd("testdata/missing.srt", TrackAuto, "", ""); err == nil {
+		t.Error("SubAdd of a missing file succeeded")
+	}
+
+	if err := m.Stop(true); err != nil {
+		t.Fatalf("Stop: %v", err)
+	}
+	if n, err := m.PlaylistCount(); err != nil || n != 2 {
+		t.Errorf("playlist-count after Stop(true) = %d, %v, want 2", n, err)
+	}
+	if err := m.Stop(false); err != nil {
+		t.Fatalf("Stop: %v", err)
+	}
+	if n, err := m.PlaylistCount(); err != nil || n != 0 {
+		t.Errorf("playlist-count after Stop(false) = %d, %v, want 0", n, err)
+	}
+}
+
+// waitFileLoaded waits for the file-loaded event.
+func waitFileLoaded(t *testing.T, m *Mpv) {
+	t.Helper()
+
+	for {
+		e := m.WaitEvent(10)
+		switch e.EventID {
+		case EventFileLoaded:
+			return
+		case EventEnd:
+			ef, _ := e.EndFile()
+			t.Fatalf("playback ended before load: %v", ef.Reason)
+		case EventNone, EventShutdown:
+			t.Fatal("file did not load")
+		}
+	}
+}
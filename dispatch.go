@@ -0,0 +1,105 @@
+package mpv
+
+import (
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// ReplyUserdataReserved marks reply userdata allocated internally by Observe, the
+// futures and the other handler-based APIs. User-chosen reply userdata must be below it,
+// the functions taking reply userdata return ErrInvalidParameter otherwise.
+const ReplyUserdataReserved uint64 = 1 << 63
+
+// clientState holds the Go-side handlers of a client handle. Client messages, log messages
+// and events with internally allocated reply userdata are routed to them from WaitEvent.
+type clientState struct {
+	mu        sync.Mutex
+	seq       uint64
+	observers map[uint64]func(EventProperty)
+	futures   map[uint64]*Future
+	hooks     map[uint64]hookHandler
+	messages  map[string]func(args []string)
+	bindings  map[string]*keyBinding
+	sections  map[string]*keySection
+	logger    *slog.Logger
+	// protocols are the stream registry tokens of RegisterProtocol, removed on destroy.
+	protocols []uintptr
+
+	// keysMu serializes the section commands of BindKey and friends, which run without mu.
+	keysMu sync.Mutex
+
+	hookTimeout time.Duration
+}
+
+func newClientState() *clientState {
+	return &clientState{
+		observers: map[uint64]func(EventProperty){},
+		futures:   map[uint64]*Future{},
+		hooks:     map[uint64]hookHandler{},
+		messages:  map[string]func(args []string){},
+		bindings:  map[string]*keyBinding{},
+		sections:  map[string]*keySection{},
+
+		hookTimeout: DefaultHookTimeout,
+	}
+}
+
+// checkReplyUserdata rejects user-chosen reply userdata in the reserved range.
+func checkReplyUserdata(id uint64) error {
+	if id&ReplyUserdataReserved != 0 {
+		return fmt.Errorf("%w: reply userdata %#x is reserved", ErrInvalidParameter, id)
+	}
+
+	return nil
+}
+
+// nextID returns a new reply userdata in the reserved range.
+func (s *clientState) nextID() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.seq++
+
+	return ReplyUserdataReserved | s.seq
+}
+
+// dispatch runs the handlers for e while its payload is still valid.
+func (m *Mpv) dispatch(e *Event) {
+	switch e.EventID {
+	case EventClientMessage:
+		m.routeScriptMessage(e)
+		return
+	case EventLogMsg:
+		m.logMessage(e)
+		return
+	case EventShutdown:
+		m.failFutures(errClientShutdown)
+		return
+	}
+
+	if e.ReplyUserdata&ReplyUserdataReserved == 0 {
+		return
+	}
+
+	s := m.state
+
+	switch e.EventID {
+	case EventPropertyChange:
+		s.mu.Lock()
+		fn := s.observers[e.ReplyUserdata]
+		s.mu.Unlock()
+
+		if fn == nil {
+			return
+		}
+		if prop, err := e.Property(); err == nil {
+			fn(prop)
+		}
+	case EventCommandReply, EventGetPropertyReply, EventSetPropertyReply:
+		m.resolveFuture(e)
+	case EventHook:
+		m.runHook(e)
+	}
+}
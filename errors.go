@@ -2,6 +2,8 @@ package mpv
 
 import (
 	"errors"
+	"strconv"
+	"strings"
 )
 
 var ErrEventQueueFull = errors.New("event queue full")
@@ -88,3 +90,66 @@ func newError(e int) error {
 
 	return err
 }
+
+// Error is returned by the client functions. It carries the mpv error code and the failed
+// operation, and unwraps to the matching sentinel error, e.g. ErrPropertyNotFound.
+type Error struct {
+	// Code is the mpv_error code.
+	Code int
+	// Op is the mpv API function without the "mpv_" prefix, e.g. "get_property".
+	Op string
+	// Name is the property, option, hook, command or file name the operation was for, if any.
+	Name string
+	// Args are the arguments of a failed command.
+	Args []string
+}
+
+// Error returns e.g. `mpv: get_property "foo": property not found`.
+func (e *Error) Error() string {
+	var b strings.Builder
+
+	b.WriteString("mpv: ")
+	b.WriteString(e.Op)
+	if e.Name != "" {
+		b.WriteString(" ")
+		b.WriteString(strconv.Quote(e.Name))
+	}
+	for _, arg := range e.Args {
+		b.WriteString(" ")
+		b.WriteString(strconv.Quote(arg))
+	}
+	b.WriteString(": ")
+	b.WriteString(newError(e.Code).Error())
+
+	return b.String()
+}
+
+// Unwrap returns the sentinel error for the code.
+func (e *Error) Unwrap() error {
+	return newError(e.Code)
+}
+
+// opError returns an *Error for the result of a failed operation, or nil on success.
+func opError(code int, op, name string, args []string) error {
+	if newError(code) == nil {
+		return nil
+	}
+
+	return &Error{Code: code, Op: op, Name: name, Args: args}
+}
+
+// nodeCommandName returns the command name of a CommandNode argument, if it has one.
+func nodeCommandName(args any) string {
+	switch v := args.(type) {
+	case []any:
+		if len(v) > 0 {
+			name, _ := v[0].(string)
+			return name
+		}
+	case map[string]any:
+		name, _ := v["name"].(string)
+		return name
+	}
+
+	return ""
+}
@@ -0,0 +1,57 @@
+package mpv
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestErrorString(t *testing.T) {
+	err := &Error{Code: errorCommand, Op: "command", Args: []string{"seek", "a b"}}
+	if got, want := err.Error(), `mpv: command "seek" "a b": error running command`; got != want {
+		t.Fatalf("Error() = %s, want %s", got, want)
+	}
+	if !errors.Is(err, ErrCommand) {
+		t.Fatal("errors.Is(err, ErrCommand) = false")
+	}
+
+	if opError(errorSuccess, "command", "", nil) != nil {
+		t.Fatal("opError of success is not nil")
+	}
+}
+
+func TestOpError(t *testing.T) {
+	m := newHeadless(t)
+
+	_, err := m.GetProperty("does-not-exist", FormatString)
+	if !errors.Is(err, ErrPropertyNotFound) {
+		t.Fatalf("GetProperty error = %v, want ErrPropertyNotFound", err)
+	}
+	var merr *Error
+	if !errors.As(err, &merr) {
+		t.Fatalf("GetProperty error %T is not an *Error", err)
+	}
+	if merr.Op != "get_property" || merr.Name != "does-not-exist" || merr.Code != errorPropertyNotFound {
+		t.Fatalf("GetProperty error = %+v", merr)
+	}
+
+	err = m.Command([]string{"no-such-command", "x"})
+	if !errors.As(err, &merr) || merr.Op != "command" || !reflect.DeepEqual(merr.Args, []string{"no-such-command", "x"}) {
+		t.Fatalf("Command error = %#v", err)
+	}
+
+	_, err = m.CommandNode(map[string]any{"name": "no-such-command"})
+	if !errors.As(err, &merr) || merr.Op != "command_node" || merr.Name != "no-such-command" {
+		t.Fatalf("CommandNode error = %#v", err)
+	}
+
+	if _, err := m.PropertyString("does-not-exist"); !errors.Is(err, ErrPropertyNotFound) {
+		t.Fatalf("PropertyString error = %v, want ErrPropertyNotFound", err)
+	}
+	if _, err := m.PropertyOsdString("does-not-exist"); !errors.Is(err, ErrPropertyNotFound) {
+		t.Fatalf("PropertyOsdString error = %v, want ErrPropertyNotFound", err)
+	}
+	if v, err := m.PropertyString("idle-active"); err != nil || v != "yes" {
+		t.Fatalf("PropertyString(idle-active) = %q, %v", v, err)
+	}
+}
@@ -0,0 +1,110 @@
+package mpv
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ToNode returns the event as a map, in the form mpv's JSON IPC and Lua scripts use:
+// the "event" key holds the event name, "id" the reply userdata, "error" the error
+// string if any, followed by the event specific fields, e.g. "name" and "data" for
+// property-change. Like Data it is only available until the next WaitEvent, except
+// for events delivered by Events, for which the map is built from the Go-owned payload
+// when ToNode is called.
+func (e *Event) ToNode() (map[string]any, error) {
+	if e.raw == nil {
+		if e.decodeErr != nil {
+			return nil, e.decodeErr
+		}
+		if e.payload == nil {
+			return nil, fmt.Errorf("%w: %s event has no mpv event to convert", ErrInvalidParameter, e.EventID)
+		}
+
+		return e.payloadNode(), nil
+	}
+
+	v, err := e.rawNode()
+	if err != nil {
+		return nil, err
+	}
+
+	node, ok := v.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("%w: mpv_event_to_node returned %T", ErrTypeMismatch, v)
+	}
+
+	return node, nil
+}
+
+// payloadNode builds the map mpv_event_to_node returns from the Go-owned payload.
+func (e *Event) payloadNode() map[string]any {
+	node := map[string]any{"event": e.EventID.String()}
+	if e.Error != nil {
+		node["error"] = e.Error.Error()
+	}
+	if e.ReplyUserdata != 0 {
+		node["id"] = int64(e.ReplyUserdata)
+	}
+
+	switch p := e.payload.(type) {
+	case StartFileEvent:
+		node["playlist_entry_id"] = p.EntryID
+	case EndFileEvent:
+		reason := p.Reason.String()
+		if reason == "" {
+			reason = "unknown"
+		}
+		node["reason"] = reason
+		node["playlist_entry_id"] = p.EntryID
+		if p.InsertID != 0 {
+			node["playlist_insert_id"] = p.InsertID
+			node["playlist_insert_num_entries"] = int64(p.InsertNumEntries)
+		}
+		if p.Reason == EndFileError && p.Error != nil {
+			node["file_error"] = p.Error.Error()
+		}
+	case LogMessageEvent:
+		node["prefix"] = p.Prefix
+		node["level"] = p.Level
+		node["text"] = p.Text
+	case ClientMessageEvent:
+		args := make([]any, len(p.Args))
+		for i, a := range p.Args {
+			args[i] = a
+		}
+		node["args"] = args
+	case PropertyChangeEvent:
+		propertyNode(node, p.EventProperty)
+	case CommandReplyEvent:
+		node["result"] = p.Result
+	case HookEvent:
+		node["hook_id"] = int64(p.ID)
+	}
+
+	return node
+}
+
+// propertyNode adds the name and, for the formats mpv converts, the data of a property.
+func propertyNode(node map[string]any, p EventProperty) {
+	node["name"] = p.Name
+
+	switch p.Format {
+	case FormatNode, FormatDouble, FormatString:
+		node["data"] = p.Data
+	case FormatFlag:
+		if v, ok := p.Data.(int); ok {
+			node["data"] = v != 0
+		}
+	}
+}
+
+// MarshalJSON implements json.Marshaler, encoding the event as ToNode returns it,
+// the same JSON that mpv's IPC server emits.
+func (e Event) MarshalJSON() ([]byte, error) {
+	node, err := e.ToNode()
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(node)
+}
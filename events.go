@@ -1,7 +1,8 @@
 package mpv
 
 import (
-	"strings"
+	"context"
+	"fmt"
 	"unsafe"
 )
 
@@ -116,6 +117,13 @@ type Event struct {
 	Error         error
 	ReplyUserdata uint64
 	Data          unsafe.Pointer
+
+	// payload is the Go-owned copy of Data for events delivered by Events, decodeErr the
+	// error decoding it, which the accessors return instead.
+	payload   Payload
+	decodeErr error
+	// raw is the C mpv_event, valid until the next WaitEvent.
+	raw unsafe.Pointer
 }
 
 type event struct {
@@ -125,95 +133,162 @@ type event struct {
 	Data          unsafe.Pointer
 }
 
-// LogMessage returns EventLogMessage.
-func (e *Event) LogMessage() EventLogMessage {
-	s := (*eventLogMessage)(e.Data)
-	var elm EventLogMessage
+// LogMessage returns the payload of an EventLogMsg event.
+func (e *Event) LogMessage() (EventLogMessage, error) {
+	p, err := e.Decode()
+	if err != nil {
+		return EventLogMessage{}, err
+	}
 
-	elm.LogLevel = s.LogLevel
-	elm.Prefix = toStr(s.Prefix)
-	elm.Level = toStr(s.Level)
-	elm.Text = strings.TrimSuffix(toStr(s.Text), "\n")
+	lm, ok := p.(LogMessageEvent)
+	if !ok {
+		return EventLogMessage{}, wrongEvent(e.EventID, "log message")
+	}
 
-	return elm
+	return lm.EventLogMessage, nil
 }
 
-// Property returns EventProperty.
-func (e *Event) Property() EventProperty {
-	s := (*eventProperty)(e.Data)
-	var ep EventProperty
-
-	ep.Name = toStr(s.Name)
-	ep.Format = Format(s.Format)
-
-	switch ep.Format {
-	case FormatNone:
-		ep.Data = nil
-	case FormatString, FormatOsdString:
-		ep.Data = toStr(s.Data)
-	case FormatFlag:
-		ep.Data = int(*(*int32)(s.Data))
-	case FormatInt64:
-		ep.Data = *(*int64)(s.Data)
-	case FormatDouble:
-		ep.Data = *(*float64)(s.Data)
-	case FormatNode:
-		ep.Data = nodeToGo(s.Data)
-	default:
-		ep.Data = nil
+// Property returns the payload of an EventPropertyChange or EventGetPropertyReply event.
+func (e *Event) Property() (EventProperty, error) {
+	p, err := e.Decode()
+	if err != nil {
+		return EventProperty{}, err
 	}
 
-	return ep
+	switch v := p.(type) {
+	case PropertyChangeEvent:
+		return v.EventProperty, nil
+	case GetPropertyReplyEvent:
+		return v.EventProperty, nil
+	}
+
+	return EventProperty{}, wrongEvent(e.EventID, "property")
 }
 
-// StartFile returns EventStartFile.
-func (e *Event) StartFile() EventStartFile {
-	s := (*EventStartFile)(e.Data)
-	var esf EventStartFile
+// StartFile returns the payload of an EventStart event.
+func (e *Event) StartFile() (EventStartFile, error) {
+	p, err := e.Decode()
+	if err != nil {
+		return EventStartFile{}, err
+	}
 
-	esf.EntryID = s.EntryID
+	sf, ok := p.(StartFileEvent)
+	if !ok {
+		return EventStartFile{}, wrongEvent(e.EventID, "start file")
+	}
 
-	return esf
+	return sf.EventStartFile, nil
 }
 
-// EndFile returns EventEndFile.
-func (e *Event) EndFile() EventEndFile {
-	s := (*eventEndFile)(e.Data)
-	var eef EventEndFile
+// EndFile returns the payload of an EventEnd event.
+func (e *Event) EndFile() (EventEndFile, error) {
+	p, err := e.Decode()
+	if err != nil {
+		return EventEndFile{}, err
+	}
 
-	eef.Reason = Reason(s.Reason)
-	eef.Error = newError(int(s.Error))
-	eef.EntryID = s.EntryID
-	eef.InsertID = s.InsertID
-	eef.InsertNumEntries = s.InsertNumEntries
+	ef, ok := p.(EndFileEvent)
+	if !ok {
+		return EventEndFile{}, wrongEvent(e.EventID, "end file")
+	}
 
-	return eef
+	return ef.EventEndFile, nil
 }
 
-// ClientMessage returns the arguments of a client message event.
-func (e *Event) ClientMessage() []string {
-	s := (*eventClientMessage)(e.Data)
-	out := make([]string, s.NumArgs)
-	if s.NumArgs > 0 {
-		args := unsafe.Slice((*unsafe.Pointer)(s.Args), int(s.NumArgs))
-		for i := range args {
-			out[i] = toStr(args[i])
-		}
+// ClientMessage returns the arguments of an EventClientMessage event.
+func (e *Event) ClientMessage() ([]string, error) {
+	p, err := e.Decode()
+	if err != nil {
+		return nil, err
+	}
+
+	cm, ok := p.(ClientMessageEvent)
+	if !ok {
+		return nil, wrongEvent(e.EventID, "client message")
+	}
+
+	return cm.Args, nil
+}
+
+// Hook returns the payload of an EventHook event. Its ID must be passed to HookContinue.
+func (e *Event) Hook() (Hook, error) {
+	p, err := e.Decode()
+	if err != nil {
+		return Hook{}, err
+	}
+
+	h, ok := p.(HookEvent)
+	if !ok {
+		return Hook{}, wrongEvent(e.EventID, "hook")
+	}
+
+	return h.Hook, nil
+}
+
+// CommandReply returns the result of an EventCommandReply event.
+func (e *Event) CommandReply() (any, error) {
+	p, err := e.Decode()
+	if err != nil {
+		return nil, err
+	}
+
+	cr, ok := p.(CommandReplyEvent)
+	if !ok {
+		return nil, wrongEvent(e.EventID, "command reply")
 	}
 
-	return out
+	return cr.Result, nil
 }
 
-// Hook returns the hook event. Its ID must be passed to HookContinue.
-func (e *Event) Hook() Hook {
-	s := (*eventHook)(e.Data)
+// wrongEvent is returned by the accessors called for an event that does not carry their payload.
+func wrongEvent(id EventID, what string) error {
+	return fmt.Errorf("%w: %s event has no %s payload", ErrInvalidParameter, id, what)
+}
 
-	return Hook{Name: toStr(s.Name), ID: s.ID}
+// owned returns a copy of e whose payload is copied into Go memory,
+// so it stays valid after the next WaitEvent. Data is nil in the copy.
+func (e *Event) owned() Event {
+	o := Event{EventID: e.EventID, Error: e.Error, ReplyUserdata: e.ReplyUserdata}
+	o.payload, o.decodeErr = e.Decode()
+
+	return o
 }
 
-// CommandReply returns the result of an asynchronous command.
-func (e *Event) CommandReply() any {
-	return nodeToGo(e.Data)
+// Events starts a goroutine that waits for events and delivers them on the returned channel,
+// with their payloads copied into Go memory; the accessors work on them as usual, Data is nil.
+// The channel is closed after EventShutdown, or when ctx is cancelled. Only one goroutine
+// may wait for events on a client handle, so do not call WaitEvent while it runs.
+func (m *Mpv) Events(ctx context.Context) <-chan Event {
+	ch := make(chan Event)
+
+	go func() {
+		defer close(ch)
+
+		stop := context.AfterFunc(ctx, m.Wakeup)
+		defer stop()
+
+		for {
+			e := m.WaitEvent(-1)
+			if ctx.Err() != nil {
+				return
+			}
+			if e.EventID == EventNone {
+				continue
+			}
+
+			select {
+			case ch <- e.owned():
+			case <-ctx.Done():
+				return
+			}
+
+			if e.EventID == EventShutdown {
+				return
+			}
+		}
+	}()
+
+	return ch
 }
 
 // EventProperty type.
@@ -229,7 +304,7 @@ type eventProperty struct {
 	Data   unsafe.Pointer
 }
 
-// EventLogMessage type.
+// EventLogMessage type. Text is the message as mpv sends it, a line ending with a newline.
 type EventLogMessage struct {
 	Prefix   string
 	Level    string
@@ -1,8 +1,13 @@
 package mpv
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"reflect"
 	"testing"
+	"time"
+	"unsafe"
 )
 
 func TestHook(t *testing.T) {
@@ -20,7 +25,10 @@ func TestHook(t *testing.T) {
 		e := m.WaitEvent(10)
 		switch e.EventID {
 		case EventHook:
-			h := e.Hook()
+			h, err := e.Hook()
+			if err != nil {
+				t.Fatalf("Hook: %v", err)
+			}
 			if h.Name != "on_load" {
 				t.Errorf("hook name = %q, want on_load", h.Name)
 			}
@@ -50,7 +58,7 @@ func TestClientMessage(t *testing.T) {
 	for i := 0; i < 100; i++ {
 		e := m.WaitEvent(10)
 		if e.EventID == EventClientMessage {
-			if got := e.ClientMessage(); !reflect.DeepEqual(got, []string{"hello", "world"}) {
+			if got, err := e.ClientMessage(); err != nil || !reflect.DeepEqual(got, []string{"hello", "world"}) {
 				t.Fatalf("ClientMessage = %#v, want [hello world]", got)
 			}
 			return
@@ -61,3 +69,224 @@ func TestClientMessage(t *testing.T) {
 	}
 	t.Fatal("no client-message event")
 }
+
+func TestEvents(t *testing.T) {
+	m := newHeadless(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events := m.Events(ctx)
+
+	if err := m.Command([]string{"loadfile", "testdata/test.mpg"}); err != nil {
+		t.Fatalf("loadfile: %v", err)
+	}
+	if err := m.CommandString("script-message owned payload"); err != nil {
+		t.Fatalf("script-message: %v", err)
+	}
+
+	var gotStart, gotMessage bool
+	timeout := time.After(10 * time.Second)
+	for !gotStart || !gotMessage {
+		select {
+		case e := <-events:
+			if e.Data != nil {
+				t.Fatalf("%v event has a C payload", e.EventID)
+			}
+			switch e.EventID {
+			case EventStart:
+				if sf, err := e.StartFile(); err != nil || sf.EntryID == 0 {
+					t.Error("start-file entry ID is 0")
+				}
+				gotStart = true
+			case EventClientMessage:
+				if got, err := e.ClientMessage(); err != nil || !reflect.DeepEqual(got, []string{"owned", "payload"}) {
+					t.Errorf("ClientMessage = %#v, want [owned payload]", got)
+				}
+				gotMessage = true
+			case EventShutdown:
+				t.Fatal("unexpected shutdown")
+			}
+		case <-timeout:
+			t.Fatal("timed out waiting for events")
+		}
+	}
+
+	cancel()
+	select {
+	case <-drain(events):
+	case <-time.After(5 * time.Second):
+		t.Fatal("event channel was not closed after cancel")
+	}
+}
+
+func TestEventToNode(t *testing.T) {
+	m := newHeadless(t)
+
+	if err := m.CommandString("script-message to node"); err != nil {
+		t.Fatalf("script-message: %v", err)
+	}
+
+	var e *Event
+	for i := 0; i < 100; i++ {
+		if e = m.WaitEvent(10); e.EventID == EventClientMessage || e.EventID == EventNone {
+			break
+		}
+	}
+	if e.EventID != EventClientMessage {
+		t.Fatal("no client-message event")
+	}
+
+	node, err := e.ToNode()
+	if err != nil {
+		t.Fatalf("ToNode: %v", err)
+	}
+	want := map[string]any{"event": "client-message", "args": []any{"to", "node"}}
+	if !reflect.DeepEqual(node, want) {
+		t.Errorf("ToNode = %#v, want %#v", node, want)
+	}
+
+	o := e.owned()
+	b, err := json.Marshal(o)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if got := string(b); got != `{"args":["to","node"],"event":"client-message"}` {
+		t.Errorf("Marshal = %s", got)
+	}
+
+	if _, err := (&Event{EventID: EventShutdown}).ToNode(); !errors.Is(err, ErrInvalidParameter) {
+		t.Errorf("ToNode without mpv event: err = %v, want ErrInvalidParameter", err)
+	}
+
+	// A log message converts the same from the mpv event and from the Go-owned payload.
+	if err := m.RequestLogMessages("info"); err != nil {
+		t.Fatalf("RequestLogMessages: %v", err)
+	}
+	if err := m.CommandString("print-text node-text"); err != nil {
+		t.Fatalf("print-text: %v", err)
+	}
+	for i := 0; i < 100; i++ {
+		if e = m.WaitEvent(10); e.EventID == EventNone {
+			break
+		}
+		if lm, err := e.LogMessage(); err != nil || lm.Text != "node-text\n" {
+			continue
+		}
+
+		raw, err := e.ToNode()
+		if err != nil {
+			t.Fatalf("ToNode of log-message: %v", err)
+		}
+		o := e.owned()
+		fromPayload, err := o.ToNode()
+		if err != nil {
+			t.Fatalf("ToNode of owned log-message: %v", err)
+		}
+		if !reflect.DeepEqual(raw, fromPayload) {
+			t.Errorf("log-message ToNode = %#v from mpv, %#v from the payload", raw, fromPayload)
+		}
+		return
+	}
+	t.Fatal("no log-message event for print-text")
+}
+
+func TestEventDecode(t *testing.T) {
+	hello := []byte("hello\x00")
+	world := []byte("world\x00")
+	args := []unsafe.Pointer{unsafe.Pointer(&hello[0]), unsafe.Pointer(&world[0])}
+	msg := eventClientMessage{NumArgs: 2, Args: unsafe.Pointer(&args[0])}
+
+	e := &Event{EventID: EventClientMessage, Data: unsafe.Pointer(&msg)}
+	p, err := e.Decode()
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	cm, ok := p.(ClientMessageEvent)
+	if !ok || !reflect.DeepEqual(cm.Args, []string{"hello", "world"}) {
+		t.Fatalf("Decode = %#v, want ClientMessageEvent{hello world}", p)
+	}
+	if p.EventID() != EventClientMessage {
+		t.Errorf("EventID = %v, want client-message", p.EventID())
+	}
+
+	if _, err := e.EndFile(); !errors.Is(err, ErrInvalidParameter) {
+		t.Errorf("EndFile on client-message: err = %v, want ErrInvalidParameter", err)
+	}
+
+	o := e.owned()
+	if got, err := o.ClientMessage(); err != nil || !reflect.DeepEqual(got, cm.Args) {
+		t.Errorf("owned ClientMessage = %#v, %v", got, err)
+	}
+
+	if p, err := (&Event{EventID: EventFileLoaded}).Decode(); err != nil || p != (SimpleEvent{ID: EventFileLoaded}) {
+		t.Errorf("Decode file-loaded = %#v, %v", p, err)
+	}
+	if _, err := (&Event{EventID: EventEnd}).Decode(); !errors.Is(err, ErrInvalidParameter) {
+		t.Errorf("Decode end-file without data: err = %v, want ErrInvalidParameter", err)
+	}
+	if _, err := (&Event{EventID: 99}).Decode(); !errors.Is(err, ErrInvalidParameter) {
+		t.Errorf("Decode unknown event: err = %v, want ErrInvalidParameter", err)
+	}
+
+	// The owned copy keeps the decode error for the accessors.
+	bad := &Event{EventID: EventEnd}
+	_, want := bad.Decode()
+	o = bad.owned()
+	if _, err := o.EndFile(); err == nil || err.Error() != want.Error() {
+		t.Errorf("owned EndFile without data: err = %v, want %v", err, want)
+	}
+	if _, err := o.ToNode(); err == nil || err.Error() != want.Error() {
+		t.Errorf("owned ToNode without data: err = %v, want %v", err, want)
+	}
+}
+
+// drain discards events until ch is closed, then closes the returned channel.
+func drain(ch <-chan Event) <-chan struct{} {
+	done := make(chan struct{})
+	go func() {
+		for range ch {
+		}
+		close(done)
+	}()
+
+	return done
+}
+
+func TestEventPayloadNode(t *testing.T) {
+	tests := []struct {
+		e    Event
+		want map[string]any
+	}{
+		{
+			Event{EventID: EventClientMessage, payload: ClientMessageEvent{Args: []string{"a", "b"}}},
+			map[string]any{"event": "client-message", "args": []any{"a", "b"}},
+		},
+		{
+			Event{EventID: EventPropertyChange, ReplyUserdata: 7, payload: PropertyChangeEvent{EventProperty{Name: "pause", Format: FormatFlag, Data: 1}}},
+			map[string]any{"event": "property-change", "id": int64(7), "name": "pause", "data": true},
+		},
+		{
+			Event{EventID: EventPropertyChange, payload: PropertyChangeEvent{EventProperty{Name: "time-pos", Format: FormatNone}}},
+			map[string]any{"event": "property-change", "name": "time-pos"},
+		},
+		{
+			Event{EventID: EventEnd, payload: EndFileEvent{EventEndFile{Reason: EndFileError, Error: ErrLoadingFailed, EntryID: 3}}},
+			map[string]any{"event": "end-file", "reason": "error", "playlist_entry_id": int64(3), "file_error": ErrLoadingFailed.Error()},
+		},
+		{
+			Event{EventID: EventCommandReply, Error: ErrCommand, ReplyUserdata: 1, payload: CommandReplyEvent{}},
+			map[string]any{"event": "command-reply", "id": int64(1), "error": ErrCommand.Error(), "result": nil},
+		},
+	}
+
+	for _, tt := range tests {
+		node, err := tt.e.ToNode()
+		if err != nil {
+			t.Fatalf("%s: ToNode: %v", tt.e.EventID, err)
+		}
+		if !reflect.DeepEqual(node, tt.want) {
+			t.Errorf("%s: ToNode = %#v, want %#v", tt.e.EventID, node, tt.want)
+		}
+	}
+}
@@ -162,7 +162,7 @@ func (p *player) tick(iup.Ihandle) int {
 		case mpv.EventShutdown:
 			return iup.CLOSE
 		case mpv.EventEnd:
-			if e.EndFile().Reason == mpv.EndFileEOF {
+			if ef, err := e.EndFile(); err == nil && ef.Reason == mpv.EndFileEOF {
 				p.paused = true
 				p.loaded = false
 				p.updateButton()
@@ -39,34 +39,42 @@ loop:
 	for {
 		e := m.WaitEvent(10000)
 
-		switch e.EventID {
-		case mpv.EventPropertyChange:
-			prop := e.Property()
-			value := prop.Data.(int)
-			fmt.Println("property:", prop.Name, value)
-		case mpv.EventFileLoaded:
-			p, err := m.GetProperty("media-title", mpv.FormatString)
-			if err != nil {
-				fmt.Println("error:", err)
-			}
-			fmt.Println("title:", p.(string))
-		case mpv.EventLogMsg:
-			msg := e.LogMessage()
-			fmt.Println("message:", msg.Text)
-		case mpv.EventStart:
-			sf := e.StartFile()
-			fmt.Println("start:", sf.EntryID)
-		case mpv.EventEnd:
-			ef := e.EndFile()
+		payload, err := e.Decode()
+		if err != nil {
+			fmt.Println("error:", err)
+			continue
+		}
+
+		switch ev := payload.(type) {
+		case mpv.PropertyChangeEvent:
+			value := ev.Data.(int)
+			fmt.Println("property:", ev.Name, value)
+		case mpv.LogMessageEvent:
+			fmt.Print("message: ", ev.Text)
+		case mpv.StartFileEvent:
+			fmt.Println("start:", ev.EntryID)
+		case mpv.EndFileEvent:
+			ef := ev.EventEndFile
 			fmt.Println("end:", ef.EntryID, ef.Reason)
 			if ef.Reason == mpv.EndFileEOF {
 				break loop
 			} else if ef.Reason == mpv.EndFileError {
 				fmt.Println("error:", ef.Error)
 			}
-		case mpv.EventShutdown:
-			fmt.Println("shutdown:", e.EventID)
-			break loop
+		case mpv.SimpleEvent:
+			switch ev.ID {
+			case mpv.EventFileLoaded:
+				p, err := m.GetProperty("media-title", mpv.FormatString)
+				if err != nil {
+					fmt.Println("error:", err)
+				}
+				fmt.Println("title:", p.(string))
+			case mpv.EventShutdown:
+				fmt.Println("shutdown:", e.EventID)
+				break loop
+			default:
+				fmt.Println("event:", e.EventID)
+			}
 		default:
 			fmt.Println("event:", e.EventID)
 		}
@@ -0,0 +1,213 @@
+package mpv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// errClientShutdown fails the pending futures of a client that shut down or was destroyed.
+var errClientShutdown = fmt.Errorf("%w: client shut down", ErrUninitialized)
+
+// Future is the pending result of an asynchronous command or property request.
+// It is resolved when WaitEvent receives the reply, so the client must run an event loop.
+// Pending futures fail with ErrUninitialized when the client shuts down or is destroyed.
+type Future struct {
+	id     uint64
+	done   chan struct{}
+	result any
+	err    error
+}
+
+// ID returns the reply userdata of the request, e.g. for AbortAsyncCommand.
+func (f *Future) ID() uint64 {
+	return f.id
+}
+
+// Done returns a channel that is closed once the reply has been received.
+func (f *Future) Done() <-chan struct{} {
+	return f.done
+}
+
+// Wait blocks until the reply has been received and returns its result and error.
+func (f *Future) Wait() (any, error) {
+	<-f.done
+
+	return f.result, f.err
+}
+
+// newFuture allocates a reply userdata and registers the future for it.
+func (m *Mpv) newFuture() *Future {
+	f := &Future{id: m.state.nextID(), done: make(chan struct{})}
+
+	m.state.mu.Lock()
+	m.state.futures[f.id] = f
+	m.state.mu.Unlock()
+
+	return f
+}
+
+// dropFuture unregisters a future whose request could not be issued.
+func (m *Mpv) dropFuture(f *Future) {
+	m.state.mu.Lock()
+	delete(m.state.futures, f.id)
+	m.state.mu.Unlock()
+}
+
+// failFutures fails all pending futures with err, when the client shuts down or is destroyed
+// and no more replies will arrive.
+func (m *Mpv) failFutures(err error) {
+	m.state.mu.Lock()
+	futures := m.state.futures
+	m.state.futures = map[uint64]*Future{}
+	m.state.mu.Unlock()
+
+	for _, f := range futures {
+		f.err = err
+		close(f.done)
+	}
+}
+
+// failFuture fails f with err unless its reply is being resolved already.
+func (m *Mpv) failFuture(f *Future, err error) {
+	m.state.mu.Lock()
+	pending := m.state.futures[f.id] == f
+	delete(m.state.futures, f.id)
+	m.state.mu.Unlock()
+
+	if pending {
+		f.err = err
+		close(f.done)
+	}
+}
+
+// resolveFuture completes the future for the reply event e, if there is one.
+func (m *Mpv) resolveFuture(e *Event) {
+	m.state.mu.Lock()
+	f := m.state.futures[e.ReplyUserdata]
+	delete(m.state.futures, e.ReplyUserdata)
+	m.state.mu.Unlock()
+
+	if f == nil {
+		return
+	}
+
+	f.err = e.Error
+	if f.err == nil {
+		switch p, err := e.Decode(); v := p.(type) {
+		case CommandReplyEvent:
+			f.result = v.Result
+		case GetPropertyReplyEvent:
+			f.result = v.Data
+		default:
+			f.err = err
+		}
+	}
+	close(f.done)
+}
+
+// CommandFuture runs the command asynchronously; the future resolves to the command result.
+func (m *Mpv) CommandFuture(cmd []string) (*Future, error) {
+	f := m.newFuture()
+	if err := m.asyncCommand(f.id, cmd); err != nil {
+		m.dropFuture(f)
+		return nil, err
+	}
+
+	return f, nil
+}
+
+// CommandNodeFuture runs a structured command asynchronously; the future resolves to the command result.
+func (m *Mpv) CommandNodeFuture(args interface{}) (*Future, error) {
+	f := m.newFuture()
+	if err := m.asyncCommandNode(f.id, args); err != nil {
+		m.dropFuture(f)
+		return nil, err
+	}
+
+	return f, nil
+}
+
+// GetPropertyFuture gets a property asynchronously; the future resolves to the value
+// in the same representation as EventProperty.Data.
+func (m *Mpv) GetPropertyFuture(name string, format Format) (*Future, error) {
+	f := m.newFuture()
+	if err := m.asyncGetProperty(name, f.id, format); err != nil {
+		m.dropFuture(f)
+		return nil, err
+	}
+
+	return f, nil
+}
+
+// SetPropertyFuture sets a property asynchronously; the future resolves to a nil result.
+func (m *Mpv) SetPropertyFuture(name string, format Format, data interface{}) (*Future, error) {
+	f := m.newFuture()
+	if err := m.asyncSetProperty(name, f.id, format, data); err != nil {
+		m.dropFuture(f)
+		return nil, err
+	}
+
+	return f, nil
+}
+
+// abortWait is how long CommandNodeContext waits for the reply of an aborted command.
+const abortWait = 100 * time.Millisecond
+
+// CommandContext runs the command asynchronously and waits for its reply. If ctx is done
+// first, the command is aborted and ctx.Err() is returned, wrapped with the command error.
+// The aborted command gets 100ms to reply; if it does not, because it cannot be aborted or
+// because the event loop has stopped, e.g. with the same ctx, ctx.Err() is returned alone
+// and the reply is dropped when it arrives. The client must run an event loop.
+func (m *Mpv) CommandContext(ctx context.Context, cmd []string) error {
+	args := make([]any, len(cmd))
+	for i, c := range cmd {
+		args[i] = c
+	}
+
+	_, err := m.CommandNodeContext(ctx, args)
+
+	return err
+}
+
+// CommandNodeContext runs a structured command asynchronously and waits for its result,
+// see CommandContext for the cancellation rules.
+func (m *Mpv) CommandNodeContext(ctx context.Context, args interface{}) (interface{}, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	f, err := m.CommandNodeFuture(args)
+	if err != nil {
+		return nil, err
+	}
+
+	select {
+	case <-f.Done():
+		return f.Wait()
+	case <-ctx.Done():
+	}
+
+	m.AbortAsyncCommand(f.ID())
+
+	// The reply may never come if the event loop stopped, e.g. with the same ctx.
+	timer := time.NewTimer(abortWait)
+	defer timer.Stop()
+
+	select {
+	case <-f.Done():
+	case <-timer.C:
+		m.failFuture(f, ctx.Err())
+	}
+
+	result, err := f.Wait()
+	switch {
+	case err == nil:
+		return result, nil
+	case errors.Is(err, ctx.Err()):
+		return nil, err
+	}
+
+	return nil, fmt.Errorf("%w: %w", ctx.Err(), err)
+}
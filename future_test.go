@@ -0,0 +1,194 @@
+package mpv
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestFuture(t *testing.T) {
+	m := newHeadless(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := drain(m.Events(ctx))
+	defer func() { cancel(); <-done }()
+
+	set, err := m.SetPropertyFuture("speed", FormatDouble, 2.0)
+	if err != nil {
+		t.Fatalf("SetPropertyFuture: %v", err)
+	}
+	if set.ID()&ReplyUserdataReserved == 0 {
+		t.Errorf("future ID %#x is not in the reserved range", set.ID())
+	}
+	if _, err := set.Wait(); err != nil {
+		t.Fatalf("set speed: %v", err)
+	}
+
+	get, err := m.GetPropertyFuture("speed", FormatDouble)
+	if err != nil {
+		t.Fatalf("GetPropertyFuture: %v", err)
+	}
+	if v, err := get.Wait(); err != nil || v != 2.0 {
+		t.Fatalf("get speed = %v, %v, want 2", v, err)
+	}
+
+	cmd, err := m.CommandFuture([]string{"expand-text", "speed=${speed}"})
+	if err != nil {
+		t.Fatalf("CommandFuture: %v", err)
+	}
+	if v, err := cmd.Wait(); err != nil || v != "speed=2.000000" {
+		t.Fatalf("expand-text = %#v, %v, want speed=2.000000", v, err)
+	}
+
+	node, err := m.CommandNodeFuture([]any{"expand-text", "${speed}"})
+	if err != nil {
+		t.Fatalf("CommandNodeFuture: %v", err)
+	}
+	if v, err := node.Wait(); err != nil || v != "2.000000" {
+		t.Fatalf("expand-text node = %#v, %v, want 2.000000", v, err)
+	}
+
+	missing, err := m.GetPropertyFuture("does-not-exist", FormatString)
+	if err != nil {
+		t.Fatalf("GetPropertyFuture: %v", err)
+	}
+	if _, err := missing.Wait(); !errors.Is(err, ErrPropertyNotFound) {
+		t.Fatalf("missing property error = %v, want ErrPropertyNotFound", err)
+	}
+}
+
+func TestCommandContext(t *testing.T) {
+	m := newHeadless(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := drain(m.Events(ctx))
+	defer func() { cancel(); <-done }()
+
+	if err := m.CommandContext(ctx, []string{"set", "speed", "1.5"}); err != nil {
+		t.Fatalf("CommandContext: %v", err)
+	}
+	if v, err := m.GetProperty("speed", FormatDouble); err != nil || v != 1.5 {
+		t.Fatalf("speed = %v, %v, want 1.5", v, err)
+	}
+
+	if runtime.GOOS == "windows" {
+		t.Skip("no sleep executable for the subprocess command")
+	}
+
+	tctx, tcancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer tcancel()
+
+	start := time.Now()
+	_, err := m.CommandNodeContext(tctx, map[string]any{
+		"name":          "subprocess",
+		"args":          []any{"sleep", "10"},
+		"playback_only": false,
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("CommandNodeContext error = %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("command was not aborted, took %v", elapsed)
+	}
+}
+
+func TestCommandContextAbortError(t *testing.T) {
+	m := newHeadless(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Stand in for the event loop: after the cancel, the aborted command replies with an error.
+	go func() {
+		<-ctx.Done()
+
+		m.state.mu.Lock()
+		var id uint64
+		for id = range m.state.futures {
+		}
+		m.state.mu.Unlock()
+
+		m.dispatch(&Event{EventID: EventCommandReply, Error: ErrCommand, ReplyUserdata: id})
+	}()
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	_, err := m.CommandNodeContext(ctx, []any{"set", "speed", "2"})
+	if !errors.Is(err, context.Canceled) || !errors.Is(err, ErrCommand) {
+		t.Fatalf("CommandNodeContext error = %v, want context.Canceled wrapped with ErrCommand", err)
+	}
+}
+
+func TestFutureShutdown(t *testing.T) {
+	m := &Mpv{state: newClientState()}
+
+	f := m.newFuture()
+	m.dispatch(&Event{EventID: EventShutdown})
+
+	select {
+	case <-f.Done():
+	case <-time.After(time.Second):
+		t.Fatal("future not completed on shutdown")
+	}
+	if _, err := f.Wait(); !errors.Is(err, ErrUninitialized) {
+		t.Errorf("Wait error = %v, want ErrUninitialized", err)
+	}
+	if n := len(m.state.futures); n != 0 {
+		t.Errorf("%d futures left after shutdown", n)
+	}
+}
+
+// TestCommandContextNoEventLoop checks that cancelling does not wait for a reply
+// that never comes because nobody runs the event loop.
+func TestCommandContextNoEventLoop(t *testing.T) {
+	m := newHeadless(t)
+
+	if runtime.GOOS == "windows" {
+		t.Skip("no sleep executable for the subprocess command")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := m.CommandNodeContext(ctx, map[string]any{
+		"name":          "subprocess",
+		"args":          []any{"sleep", "10"},
+		"playback_only": false,
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("CommandNodeContext error = %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("cancel took %v without an event loop", elapsed)
+	}
+
+	m.state.mu.Lock()
+	n := len(m.state.futures)
+	m.state.mu.Unlock()
+	if n != 0 {
+		t.Errorf("%d futures left after cancel", n)
+	}
+}
+
+func TestReplyUserdataReservedRejected(t *testing.T) {
+	m := &Mpv{state: newClientState()}
+	id := ReplyUserdataReserved | 1
+
+	for name, call := range map[string]func() error{
+		"CommandAsync":      func() error { return m.CommandAsync(id, []string{"stop"}) },
+		"CommandNodeAsync":  func() error { return m.CommandNodeAsync(id, []any{"stop"}) },
+		"GetPropertyAsync":  func() error { return m.GetPropertyAsync("pause", id, FormatFlag) },
+		"SetPropertyAsync":  func() error { return m.SetPropertyAsync("pause", id, FormatFlag, true) },
+		"ObserveProperty":   func() error { return m.ObserveProperty(id, "pause", FormatFlag) },
+		"UnobserveProperty": func() error { return m.UnobserveProperty(id) },
+		"HookAdd":           func() error { return m.HookAdd(id, "on_load", 0) },
+	} {
+		if err := call(); !errors.Is(err, ErrInvalidParameter) {
+			t.Errorf("%s with a reserved ID: err = %v, want ErrInvalidParameter", name, err)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package mpv
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Hook names for OnHook and HookAdd.
+const (
+	HookOnLoad            = "on_load"
+	HookOnLoadFail        = "on_load_fail"
+	HookOnPreloaded       = "on_preloaded"
+	HookOnUnload          = "on_unload"
+	HookOnBeforeStartFile = "on_before_start_file"
+	HookOnAfterEndFile    = "on_after_end_file"
+)
+
+// DefaultHookTimeout is how long an OnHook handler may run before the hook is continued anyway.
+const DefaultHookTimeout = 30 * time.Second
+
+type hookHandler struct {
+	name string
+	fn   func(ctx context.Context, h Hook) error
+}
+
+// OnHook registers fn for the named hook. Handlers run in priority order, higher first, and in
+// registration order for equal priorities. The hook is always continued when fn returns, panics,
+// or exceeds the hook timeout, in which case ctx is cancelled; errors are logged to the SetLogger
+// logger, or the default slog logger.
+// fn runs on its own goroutine, so it may use the client, but the client must run an event loop.
+func (m *Mpv) OnHook(name string, priority int, fn func(ctx context.Context, h Hook) error) error {
+	id := m.state.nextID()
+
+	m.state.mu.Lock()
+	m.state.hooks[id] = hookHandler{name: name, fn: fn}
+	m.state.mu.Unlock()
+
+	err := m.addHook(id, name, priority)
+	if err != nil {
+		m.state.mu.Lock()
+		delete(m.state.hooks, id)
+		m.state.mu.Unlock()
+	}
+
+	return err
+}
+
+// SetHookTimeout sets the time OnHook handlers may run, DefaultHookTimeout by default.
+func (m *Mpv) SetHookTimeout(d time.Duration) {
+	m.state.mu.Lock()
+	defer m.state.mu.Unlock()
+
+	m.state.hookTimeout = d
+}
+
+// runHook runs the handler for a hook event and continues the hook when it is done.
+func (m *Mpv) runHook(e *Event) {
+	m.state.mu.Lock()
+	h, ok := m.state.hooks[e.ReplyUserdata]
+	timeout := m.state.hookTimeout
+	m.state.mu.Unlock()
+
+	if !ok {
+		return
+	}
+
+	hook, err := e.Hook()
+	if err != nil {
+		m.logger().Error("mpv: invalid hook event", "hook", h.name, "error", err)
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- fmt.Errorf("panic: %v", r)
+			}
+		}()
+		done <- h.fn(ctx, hook)
+	}()
+
+	go func() {
+		defer cancel()
+
+		var err error
+		select {
+		case err = <-done:
+		case <-ctx.Done():
+			err = ctx.Err()
+		}
+		if err != nil {
+			m.logger().Error("mpv: hook handler failed", "hook", h.name, "error", err)
+		}
+
+		if err := m.HookContinue(hook.ID); err != nil {
+			m.logger().Error("mpv: cannot continue hook", "hook", h.name, "error", err)
+		}
+	}()
+}
@@ -0,0 +1,66 @@
+package mpv
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestOnHook(t *testing.T) {
+	m := newHeadless(t)
+	m.SetHookTimeout(200 * time.Millisecond)
+
+	var mu sync.Mutex
+	var order []string
+	record := func(s string) {
+		mu.Lock()
+		order = append(order, s)
+		mu.Unlock()
+	}
+
+	// Registered first, but runs last because of its lower priority.
+	if err := m.OnHook(HookOnLoad, -10, func(ctx context.Context, h Hook) error {
+		record("low")
+		return errors.New("handler error")
+	}); err != nil {
+		t.Fatalf("OnHook: %v", err)
+	}
+	if err := m.OnHook(HookOnLoad, 10, func(ctx context.Context, h Hook) error {
+		if h.Name != HookOnLoad {
+			t.Errorf("hook name = %q, want %q", h.Name, HookOnLoad)
+		}
+		record("panic")
+		panic("handler panic")
+	}); err != nil {
+		t.Fatalf("OnHook: %v", err)
+	}
+	if err := m.OnHook(HookOnLoad, 0, func(ctx context.Context, h Hook) error {
+		record("timeout")
+		<-ctx.Done()
+		return ctx.Err()
+	}); err != nil {
+		t.Fatalf("OnHook: %v", err)
+	}
+
+	if err := m.Command([]string{"loadfile", "testdata/test.mpg"}); err != nil {
+		t.Fatalf("loadfile: %v", err)
+	}
+
+	for {
+		e := m.WaitEvent(10)
+		if e.EventID == EventFileLoaded {
+			break
+		}
+		if e.EventID == EventNone || e.EventID == EventShutdown {
+			t.Fatal("hooks were not continued")
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(order) != 3 || order[0] != "panic" || order[1] != "timeout" || order[2] != "low" {
+		t.Fatalf("handler order = %v, want [panic timeout low]", order)
+	}
+}
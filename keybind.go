@@ -0,0 +1,317 @@
+package mpv
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// KeyPhase is the phase of a key event.
+type KeyPhase string
+
+// Key phases; simple bindings only see KeyDown for keys, KeyUp for mouse buttons, KeyPress,
+// and KeyRepeat when repeatable, like the key bindings of Lua scripts.
+const (
+	KeyDown   KeyPhase = "down"
+	KeyUp     KeyPhase = "up"
+	KeyRepeat KeyPhase = "repeat"
+	KeyPress  KeyPhase = "press"
+)
+
+var keyPhaseMap = map[byte]KeyPhase{
+	'd': KeyDown,
+	'u': KeyUp,
+	'r': KeyRepeat,
+	'p': KeyPress,
+}
+
+// KeyEvent is passed to the functions bound with BindKey.
+type KeyEvent struct {
+	// Name is the binding name, Key the key that triggered it, e.g. "Ctrl+o".
+	Name     string
+	Key      string
+	Text     string
+	Phase    KeyPhase
+	Mouse    bool
+	Canceled bool
+	Scale    float64
+	Arg      string
+}
+
+// BindingOption configures a key binding.
+type BindingOption func(*keyBinding)
+
+// BindForced makes the binding take precedence over the user's input.conf, the default
+// is a weak binding that input.conf can override.
+func BindForced() BindingOption {
+	return func(b *keyBinding) { b.forced = true }
+}
+
+// BindRepeatable calls the function on key repeat events too.
+func BindRepeatable() BindingOption {
+	return func(b *keyBinding) { b.repeatable = true }
+}
+
+// BindComplex calls the function for every phase, including KeyUp.
+func BindComplex() BindingOption {
+	return func(b *keyBinding) { b.complex = true }
+}
+
+// BindName sets the binding name, which can also be triggered with the script-binding
+// command as "<client name>/<name>". By default a unique name is generated.
+func BindName(name string) BindingOption {
+	return func(b *keyBinding) { b.name = name }
+}
+
+// BindSection puts the binding into a named section, which is enabled and disabled as a
+// group with EnableSection and DisableSection. By default bindings go into the "" section.
+func BindSection(section string) BindingOption {
+	return func(b *keyBinding) { b.section = section }
+}
+
+type keyBinding struct {
+	key        string
+	name       string
+	section    string
+	forced     bool
+	repeatable bool
+	complex    bool
+	fn         func(KeyEvent)
+}
+
+// keySection is a group of bindings, backed by one forced and one weak mpv input section.
+// defined records which of the two, indexed by forced, have been defined in mpv.
+type keySection struct {
+	disabled bool
+	defined  [2]bool
+	bindings []*keyBinding
+}
+
+// BindKey calls fn when key is pressed, using input sections and script-binding like the
+// key bindings of Lua scripts. A binding for the same key in the same section and mode
+// replaces the previous one. fn runs on the goroutine calling WaitEvent (or Events).
+func (m *Mpv) BindKey(key string, fn func(KeyEvent), opts ...BindingOption) error {
+	b := &keyBinding{key: key, fn: fn}
+	for _, opt := range opts {
+		opt(b)
+	}
+
+	s := m.state
+	s.keysMu.Lock()
+	defer s.keysMu.Unlock()
+
+	s.mu.Lock()
+	if b.name == "" {
+		s.seq++
+		b.name = "__keybinding" + strconv.FormatUint(s.seq, 10)
+	}
+
+	sec := s.sections[b.section]
+	if sec == nil {
+		sec = &keySection{}
+		s.sections[b.section] = sec
+	}
+
+	for i, old := range sec.bindings {
+		if old.key == key && old.forced == b.forced {
+			delete(s.bindings, old.name)
+			sec.bindings = append(sec.bindings[:i], sec.bindings[i+1:]...)
+			break
+		}
+	}
+	sec.bindings = append(sec.bindings, b)
+	s.bindings[b.name] = b
+
+	cmds := m.defineSection(b.section, sec, b.forced)
+	s.mu.Unlock()
+
+	return m.runCommands(cmds)
+}
+
+// UnbindKey removes the binding with the given name.
+func (m *Mpv) UnbindKey(name string) error {
+	s := m.state
+	s.keysMu.Lock()
+	defer s.keysMu.Unlock()
+
+	s.mu.Lock()
+	b := s.bindings[name]
+	if b == nil {
+		s.mu.Unlock()
+		return nil
+	}
+	delete(s.bindings, name)
+
+	sec := s.sections[b.section]
+	for i, old := range sec.bindings {
+		if old == b {
+			sec.bindings = append(sec.bindings[:i], sec.bindings[i+1:]...)
+			break
+		}
+	}
+
+	cmds := m.defineSection(b.section, sec, b.forced)
+	s.mu.Unlock()
+
+	return m.runCommands(cmds)
+}
+
+// EnableSection enables the bindings of the named section.
+func (m *Mpv) EnableSection(section string) error {
+	return m.setSectionEnabled(section, true)
+}
+
+// DisableSection disables the bindings of the named section until it is enabled again.
+func (m *Mpv) DisableSection(section string) error {
+	return m.setSectionEnabled(section, false)
+}
+
+func (m *Mpv) setSectionEnabled(section string, enable bool) error {
+	s := m.state
+	s.keysMu.Lock()
+	defer s.keysMu.Unlock()
+
+	s.mu.Lock()
+	sec := s.sections[section]
+	if sec == nil {
+		s.mu.Unlock()
+		return fmt.Errorf("%w: no key bindings in section %q", ErrInvalidParameter, section)
+	}
+	sec.disabled = !enable
+
+	var cmds [][]string
+	for _, forced := range []bool{false, true} {
+		if !sec.defined[boolInt(forced)] {
+			continue
+		}
+		name := m.sectionName(section, forced)
+		if enable {
+			cmds = append(cmds, []string{"enable-section", name, "allow-hide-cursor+allow-vo-dragging"})
+		} else {
+			cmds = append(cmds, []string{"disable-section", name})
+		}
+	}
+	s.mu.Unlock()
+
+	return m.runCommands(cmds)
+}
+
+// runCommands runs the section commands in order, stopping at the first error. They are
+// run after the state lock is released, BindKey and friends serialize them with keysMu.
+func (m *Mpv) runCommands(cmds [][]string) error {
+	for _, cmd := range cmds {
+		if err := m.Command(cmd); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// sectionName returns the mpv input section for a binding section and mode.
+func (m *Mpv) sectionName(section string, forced bool) string {
+	name := "input_"
+	if forced {
+		name = "input_forced_"
+	}
+	name += m.Name()
+	if section != "" {
+		name += "_" + section
+	}
+
+	return name
+}
+
+// defineSection returns the commands that redefine the mpv input section holding the
+// bindings of sec with the given mode. The caller must hold the state lock.
+func (m *Mpv) defineSection(section string, sec *keySection, forced bool) [][]string {
+	client := m.Name()
+
+	var contents strings.Builder
+	for _, b := range sec.bindings {
+		if b.forced != forced {
+			continue
+		}
+		contents.WriteString(b.key)
+		if b.repeatable || b.complex {
+			contents.WriteString(" repeatable")
+		}
+		contents.WriteString(" script-binding " + client + "/" + b.name + "\n")
+	}
+
+	flags := "default"
+	if forced {
+		flags = "force"
+	}
+
+	sec.defined[boolInt(forced)] = true
+
+	name := m.sectionName(section, forced)
+	cmds := [][]string{{"define-section", name, contents.String(), flags}}
+	if !sec.disabled {
+		cmds = append(cmds, []string{"enable-section", name, "allow-hide-cursor+allow-vo-dragging"})
+	}
+
+	return cmds
+}
+
+// routeKeyBinding handles the key-binding client message that script-binding sends:
+// key-binding <name> <state> [<key name> <key text> <scale> <arg>].
+func (m *Mpv) routeKeyBinding(args []string) bool {
+	if len(args) < 3 || args[0] != "key-binding" {
+		return false
+	}
+
+	m.state.mu.Lock()
+	b := m.state.bindings[args[1]]
+	m.state.mu.Unlock()
+
+	if b == nil {
+		return false
+	}
+
+	state := args[2]
+	if state == "" {
+		return true
+	}
+
+	ev := KeyEvent{
+		Name:     b.name,
+		Phase:    keyPhaseMap[state[0]],
+		Mouse:    len(state) > 1 && state[1] == 'm',
+		Canceled: len(state) > 2 && state[2] == 'c',
+		Scale:    1,
+	}
+	if len(args) > 3 {
+		ev.Key = args[3]
+	}
+	if len(args) > 4 {
+		ev.Text = args[4]
+	}
+	if len(args) > 5 {
+		if scale, err := strconv.ParseFloat(args[5], 64); err == nil {
+			ev.Scale = scale
+		}
+	}
+	if len(args) > 6 {
+		ev.Arg = args[6]
+	}
+
+	// Like Lua scripts, simple bindings fire on down for keys and on up for mouse buttons.
+	trigger := KeyDown
+	if ev.Mouse {
+		trigger = KeyUp
+	}
+
+	switch {
+	case b.complex:
+	case ev.Phase == trigger || ev.Phase == KeyPress:
+	case ev.Phase == KeyRepeat && b.repeatable:
+	default:
+		return true
+	}
+
+	b.fn(ev)
+
+	return true
+}
@@ -0,0 +1,102 @@
+package mpv
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBindKey(t *testing.T) {
+	m := newHeadless(t)
+
+	pump := func(cond func() bool) bool {
+		for i := 0; i < 50 && !cond(); i++ {
+			if e := m.WaitEvent(0.5); e.EventID == EventNone {
+				break
+			}
+		}
+		return cond()
+	}
+
+	var simple []KeyEvent
+	if err := m.BindKey("Ctrl+o", func(e KeyEvent) { simple = append(simple, e) }, BindName("open")); err != nil {
+		t.Fatalf("BindKey: %v", err)
+	}
+	if err := m.Command([]string{"keypress", "Ctrl+o"}); err != nil {
+		t.Fatalf("keypress: %v", err)
+	}
+	if !pump(func() bool { return len(simple) > 0 }) {
+		t.Fatal("simple binding was not called")
+	}
+	if e := simple[0]; e.Name != "open" || (e.Phase != KeyDown && e.Phase != KeyPress) {
+		t.Fatalf("simple event = %+v", e)
+	}
+
+	var phases []KeyPhase
+	if err := m.BindKey("MBTN_RIGHT", func(e KeyEvent) { phases = append(phases, e.Phase) }, BindComplex(), BindForced()); err != nil {
+		t.Fatalf("BindKey complex: %v", err)
+	}
+	if err := m.Command([]string{"keydown", "MBTN_RIGHT"}); err != nil {
+		t.Fatalf("keydown: %v", err)
+	}
+	if err := m.Command([]string{"keyup", "MBTN_RIGHT"}); err != nil {
+		t.Fatalf("keyup: %v", err)
+	}
+	if !pump(func() bool { return len(phases) >= 2 }) {
+		t.Fatalf("complex binding phases = %v, want down and up", phases)
+	}
+	if phases[0] != KeyDown || phases[len(phases)-1] != KeyUp {
+		t.Fatalf("complex binding phases = %v, want down first and up last", phases)
+	}
+
+	var scoped int
+	if err := m.BindKey("F5", func(KeyEvent) { scoped++ }, BindSection("dialog")); err != nil {
+		t.Fatalf("BindKey scoped: %v", err)
+	}
+	if err := m.DisableSection("dialog"); err != nil {
+		t.Fatalf("DisableSection: %v", err)
+	}
+	if err := m.Command([]string{"keypress", "F5"}); err != nil {
+		t.Fatalf("keypress: %v", err)
+	}
+	if pump(func() bool { return scoped > 0 }) {
+		t.Fatal("binding in a disabled section was called")
+	}
+	if err := m.EnableSection("dialog"); err != nil {
+		t.Fatalf("EnableSection: %v", err)
+	}
+	if err := m.Command([]string{"keypress", "F5"}); err != nil {
+		t.Fatalf("keypress: %v", err)
+	}
+	if !pump(func() bool { return scoped > 0 }) {
+		t.Fatal("binding in a re-enabled section was not called")
+	}
+
+	if err := m.DisableSection("missing"); err == nil {
+		t.Fatal("DisableSection of an unknown section succeeded")
+	}
+}
+
+func TestRouteKeyBindingPhases(t *testing.T) {
+	m := &Mpv{state: newClientState()}
+
+	var got []string
+	m.state.bindings["b"] = &keyBinding{name: "b", fn: func(e KeyEvent) { got = append(got, e.Key+" "+string(e.Phase)) }}
+
+	for _, msg := range [][]string{
+		{"key-binding", "b", "d--", "a"},
+		{"key-binding", "b", "u--", "a"},
+		{"key-binding", "b", "r--", "a"},
+		{"key-binding", "b", "p--", "a"},
+		{"key-binding", "b", "dm-", "MBTN_LEFT"},
+		{"key-binding", "b", "um-", "MBTN_LEFT"},
+	} {
+		if !m.routeKeyBinding(msg) {
+			t.Fatalf("routeKeyBinding(%v) = false", msg)
+		}
+	}
+
+	want := []string{"a down", "a press", "MBTN_LEFT up"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("fired %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,97 @@
+package mpv
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+)
+
+// Custom slog levels for the mpv log levels without a slog equivalent.
+const (
+	LevelFatal   = slog.LevelError + 4
+	LevelVerbose = slog.LevelDebug + 2
+	LevelTrace   = slog.LevelDebug - 4
+)
+
+// logLevels maps mpv log levels to slog levels, from the least to the most verbose.
+var logLevels = []struct {
+	name  string
+	level slog.Level
+}{
+	{"fatal", LevelFatal},
+	{"error", slog.LevelError},
+	{"warn", slog.LevelWarn},
+	{"info", slog.LevelInfo},
+	{"v", LevelVerbose},
+	{"debug", slog.LevelDebug},
+	{"trace", LevelTrace},
+}
+
+// SlogLevel returns the slog level for an mpv log level name, as in EventLogMessage.Level.
+func SlogLevel(level string) slog.Level {
+	for _, l := range logLevels {
+		if l.name == level {
+			return l.level
+		}
+	}
+
+	return slog.LevelInfo
+}
+
+// LogLevelFor returns the most verbose mpv log level the handler is enabled for, suitable
+// for RequestLogMessages, or "no" if it is enabled for none of them.
+func LogLevelFor(h slog.Handler) string {
+	level := "no"
+	for _, l := range logLevels {
+		if h.Enabled(context.Background(), l.level) {
+			level = l.name
+		}
+	}
+
+	return level
+}
+
+// SetLogger forwards the log messages of this client to logger, with the mpv module prefix
+// as the "prefix" attribute. The requested log level is derived from the logger's handler.
+// A nil logger stops forwarding and disables log messages.
+func (m *Mpv) SetLogger(logger *slog.Logger) error {
+	level := "no"
+	if logger != nil {
+		level = LogLevelFor(logger.Handler())
+	}
+
+	m.state.mu.Lock()
+	m.state.logger = logger
+	m.state.mu.Unlock()
+
+	return m.RequestLogMessages(level)
+}
+
+// logger returns the logger set with SetLogger, or the default slog logger.
+func (m *Mpv) logger() *slog.Logger {
+	m.state.mu.Lock()
+	defer m.state.mu.Unlock()
+
+	if m.state.logger != nil {
+		return m.state.logger
+	}
+
+	return slog.Default()
+}
+
+// logMessage forwards a log message event to the logger set with SetLogger.
+func (m *Mpv) logMessage(e *Event) {
+	m.state.mu.Lock()
+	logger := m.state.logger
+	m.state.mu.Unlock()
+
+	if logger == nil {
+		return
+	}
+
+	msg, err := e.LogMessage()
+	if err != nil {
+		return
+	}
+	logger.LogAttrs(context.Background(), SlogLevel(msg.Level), strings.TrimSuffix(msg.Text, "\n"), slog.String("prefix", msg.Prefix))
+}
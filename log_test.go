@@ -0,0 +1,72 @@
+package mpv
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestLogLevels(t *testing.T) {
+	if got := SlogLevel("warn"); got != slog.LevelWarn {
+		t.Errorf("SlogLevel(warn) = %v", got)
+	}
+	if got := SlogLevel("v"); got != LevelVerbose {
+		t.Errorf("SlogLevel(v) = %v", got)
+	}
+
+	tests := []struct {
+		level slog.Level
+		want  string
+	}{
+		{LevelTrace, "trace"},
+		{slog.LevelDebug, "debug"},
+		{LevelVerbose, "v"},
+		{slog.LevelInfo, "info"},
+		{slog.LevelWarn, "warn"},
+		{slog.LevelError, "error"},
+		{LevelFatal, "fatal"},
+		{LevelFatal + 1, "no"},
+	}
+	for _, tc := range tests {
+		h := slog.NewTextHandler(&bytes.Buffer{}, &slog.HandlerOptions{Level: tc.level})
+		if got := LogLevelFor(h); got != tc.want {
+			t.Errorf("LogLevelFor(%v) = %q, want %q", tc.level, got, tc.want)
+		}
+	}
+}
+
+func TestSetLogger(t *testing.T) {
+	m := newHeadless(t)
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: LevelVerbose}))
+	if err := m.SetLogger(logger); err != nil {
+		t.Fatalf("SetLogger: %v", err)
+	}
+
+	if err := m.Command([]string{"loadfile", "testdata/test.mpg"}); err != nil {
+		t.Fatalf("loadfile: %v", err)
+	}
+	for {
+		e := m.WaitEvent(10)
+		if e.EventID == EventFileLoaded {
+			break
+		}
+		if e.EventID == EventNone || e.EventID == EventShutdown {
+			t.Fatal("file did not load")
+		}
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "prefix=") {
+		t.Fatalf("no log messages with a prefix were forwarded:\n%s", out)
+	}
+	if strings.Contains(out, "level=DEBUG ") {
+		t.Fatalf("debug messages were forwarded to a verbose logger:\n%s", out)
+	}
+
+	if err := m.SetLogger(nil); err != nil {
+		t.Fatalf("SetLogger(nil): %v", err)
+	}
+}
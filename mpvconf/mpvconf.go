@@ -0,0 +1,492 @@
+// Package mpvconf parses and writes mpv config files (mpv.conf) in pure Go.
+//
+// The syntax follows mpv's own parser: one option per line as key=value, key alone for
+// flags, an optional leading "--", values quoted with "..." or '...' or with mpv's fixed
+// length quoting %N%value, and # comments. Lines after a [name] header belong to that
+// profile, [default] switches back to the top level. Comments and blank lines are kept,
+// so a parsed document can be edited and written back.
+package mpvconf
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Profile options that describe a profile rather than set an option.
+const (
+	KeyProfileDesc    = "profile-desc"
+	KeyProfileCond    = "profile-cond"
+	KeyProfileRestore = "profile-restore"
+	KeyInclude        = "include"
+)
+
+// Document is a parsed config file.
+type Document struct {
+	// Sections in file order. The first one is the top level and has an empty name,
+	// the others come from [name] headers, a name may appear more than once.
+	Sections []*Section
+	// Dir is the directory relative include paths are resolved against, set by ParseFile.
+	// If it is empty they are resolved against the working directory.
+	Dir string
+}
+
+// Section is the top level of the file or a [name] profile section.
+type Section struct {
+	Name string
+	// Comment is the comment after the [name] header, without the '#'.
+	Comment string
+	Lines   []*Line
+}
+
+// Line is an option, a comment or a blank line.
+type Line struct {
+	// Key is the option name without a leading "--", empty for comment and blank lines.
+	Key   string
+	Value string
+	// Flag is set for options given without '=', e.g. "fs" or "--no-audio".
+	Flag bool
+	// Comment is the comment text after the '#', on its own line or after the option.
+	Comment string
+}
+
+// IsOption reports whether the line sets an option.
+func (l *Line) IsOption() bool {
+	return l.Key != ""
+}
+
+// Option returns the name and value to pass to SetOptionString. A flag sets the
+// option to "yes", or to "no" for the "no-" prefixed form.
+func (l *Line) Option() (name, value string) {
+	if !l.Flag {
+		return l.Key, l.Value
+	}
+	if name, ok := strings.CutPrefix(l.Key, "no-"); ok {
+		return name, "no"
+	}
+
+	return l.Key, "yes"
+}
+
+// ParseError describes a syntax error.
+type ParseError struct {
+	File string
+	Line int
+	Msg  string
+}
+
+func (e *ParseError) Error() string {
+	if e.File != "" {
+		return fmt.Sprintf("%s:%d: %s", e.File, e.Line, e.Msg)
+	}
+
+	return fmt.Sprintf("line %d: %s", e.Line, e.Msg)
+}
+
+// ParseFile parses the named file. Includes are kept as include options, relative to the
+// directory of the file, see Document.Includes.
+func ParseFile(name string) (*Document, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	doc, err := Parse(f)
+	var perr *ParseError
+	if errors.As(err, &perr) {
+		perr.File = name
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	doc.Dir, err = filepath.Abs(filepath.Dir(name))
+	if err != nil {
+		return nil, err
+	}
+
+	return doc, nil
+}
+
+// Parse parses a config file from r.
+func Parse(r io.Reader) (*Document, error) {
+	cur := &Section{}
+	doc := &Document{Sections: []*Section{cur}}
+
+	sc := bufio.NewScanner(r)
+	sc.Buffer(nil, 1<<20)
+
+	for n := 1; sc.Scan(); n++ {
+		text := strings.TrimSpace(sc.Text())
+		if n == 1 {
+			text = strings.TrimPrefix(text, "\ufeff")
+		}
+
+		switch {
+		case text == "":
+			cur.Lines = append(cur.Lines, &Line{})
+		case text[0] == '#':
+			cur.Lines = append(cur.Lines, &Line{Comment: text[1:]})
+		case text[0] == '[':
+			name, rest, ok := strings.Cut(text[1:], "]")
+			if !ok {
+				return nil, &ParseError{Line: n, Msg: "missing ']' in profile name"}
+			}
+			cur = &Section{Name: name}
+			if c, ok := strings.CutPrefix(strings.TrimSpace(rest), "#"); ok {
+				cur.Comment = c
+			}
+			doc.Sections = append(doc.Sections, cur)
+		default:
+			l, err := parseLine(text)
+			if err != nil {
+				return nil, &ParseError{Line: n, Msg: err.Error()}
+			}
+			cur.Lines = append(cur.Lines, l)
+		}
+	}
+
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+
+	return doc, nil
+}
+
+func parseLine(line string) (*Line, error) {
+	line = strings.TrimPrefix(line, "--")
+
+	i := 0
+	for i < len(line) && isKeyChar(line[i]) {
+		i++
+	}
+	if i == 0 {
+		return nil, fmt.Errorf("invalid option name in %q", line)
+	}
+
+	l := &Line{Key: line[:i], Flag: true}
+	rest := strings.TrimLeft(line[i:], " \t")
+
+	if after, ok := strings.CutPrefix(rest, "="); ok {
+		l.Flag = false
+		rest = strings.TrimLeft(after, " \t")
+
+		switch {
+		case rest != "" && (rest[0] == '"' || rest[0] == '\''):
+			end := strings.IndexByte(rest[1:], rest[0])
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated quotes for option %q", l.Key)
+			}
+			l.Value = rest[1 : end+1]
+			rest = rest[end+2:]
+		case rest != "" && rest[0] == '%':
+			num, tail, ok := strings.Cut(rest[1:], "%")
+			size, err := strconv.Atoi(num)
+			if !ok || err != nil || size < 0 || size > len(tail) {
+				return nil, fmt.Errorf("fixed-length quoting expected for option %q", l.Key)
+			}
+			l.Value = tail[:size]
+			rest = tail[size:]
+		default:
+			value, comment, _ := strings.Cut(rest, "#")
+			l.Value = strings.TrimSpace(value)
+			l.Comment = comment
+			return l, nil
+		}
+	}
+
+	rest = strings.TrimLeft(rest, " \t")
+	switch {
+	case rest == "":
+	case rest[0] == '#':
+		l.Comment = rest[1:]
+	default:
+		return nil, fmt.Errorf("extra characters after option %q: %q", l.Key, rest)
+	}
+
+	return l, nil
+}
+
+func isKeyChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '-'
+}
+
+// WriteTo writes the document in config file syntax. Values are quoted only when needed.
+func (d *Document) WriteTo(w io.Writer) (int64, error) {
+	var buf bytes.Buffer
+
+	for i, s := range d.Sections {
+		if i > 0 || s.Name != "" {
+			buf.WriteString("[" + s.Name + "]")
+			if s.Comment != "" {
+				buf.WriteString(" #" + s.Comment)
+			}
+			buf.WriteByte('\n')
+		}
+		for _, l := range s.Lines {
+			writeLine(&buf, l)
+		}
+	}
+
+	return buf.WriteTo(w)
+}
+
+// String returns the document in config file syntax.
+func (d *Document) String() string {
+	var sb strings.Builder
+	_, _ = d.WriteTo(&sb)
+
+	return sb.String()
+}
+
+func writeLine(buf *bytes.Buffer, l *Line) {
+	if l.IsOption() {
+		buf.WriteString(l.Key)
+		if !l.Flag {
+			buf.WriteString("=" + quote(l.Value))
+		}
+		if l.Comment != "" {
+			buf.WriteString(" ")
+		}
+	}
+	if l.Comment != "" {
+		buf.WriteString("#" + l.Comment)
+	}
+	buf.WriteByte('\n')
+}
+
+// quote returns v quoted so that Parse reads it back unchanged.
+func quote(v string) string {
+	switch {
+	case v == "":
+		return v
+	case v == strings.TrimSpace(v) && !strings.Contains(v, "#") && !strings.ContainsRune(`"'%`, rune(v[0])):
+		return v
+	case !strings.Contains(v, `"`):
+		return `"` + v + `"`
+	case !strings.Contains(v, "'"):
+		return "'" + v + "'"
+	}
+
+	return "%" + strconv.Itoa(len(v)) + "%" + v
+}
+
+// Top returns the top level section.
+func (d *Document) Top() *Section {
+	if len(d.Sections) == 0 || d.Sections[0].Name != "" {
+		d.Sections = append([]*Section{{}}, d.Sections...)
+	}
+
+	return d.Sections[0]
+}
+
+// Options returns the top level options, including those of [default] sections.
+func (d *Document) Options() []*Line {
+	var lines []*Line
+	for _, s := range d.Sections {
+		if s.Name == "" || s.Name == "default" {
+			lines = append(lines, s.Options()...)
+		}
+	}
+
+	return lines
+}
+
+// Profiles returns the profile names in the order they are first defined.
+func (d *Document) Profiles() []string {
+	var names []string
+	seen := map[string]bool{}
+	for _, s := range d.Sections {
+		if s.Name == "" || s.Name == "default" || seen[s.Name] {
+			continue
+		}
+		seen[s.Name] = true
+		names = append(names, s.Name)
+	}
+
+	return names
+}
+
+// Profile returns the first section of the named profile, or nil.
+func (d *Document) Profile(name string) *Section {
+	for _, s := range d.Sections {
+		if s.Name == name {
+			return s
+		}
+	}
+
+	return nil
+}
+
+// AddProfile returns the named profile, appending an empty section if it does not exist.
+func (d *Document) AddProfile(name string) *Section {
+	if s := d.Profile(name); s != nil {
+		return s
+	}
+
+	s := &Section{Name: name}
+	d.Sections = append(d.Sections, s)
+
+	return s
+}
+
+// Includes returns the paths of the top level include options, see IncludePath.
+func (d *Document) Includes() []string {
+	var paths []string
+	for _, l := range d.Options() {
+		if l.Key == KeyInclude {
+			paths = append(paths, d.IncludePath(l.Value))
+		}
+	}
+
+	return paths
+}
+
+// IncludePath returns the path of an include option value, made absolute against Dir.
+// Paths mpv expands itself, starting with '~', and protocols such as memory:// are kept.
+func (d *Document) IncludePath(value string) string {
+	if value == "" || filepath.IsAbs(value) || value[0] == '~' || strings.Contains(value, "://") {
+		return value
+	}
+
+	path, err := filepath.Abs(filepath.Join(d.Dir, value))
+	if err != nil {
+		return value
+	}
+
+	return path
+}
+
+// Get returns the value of the last line setting key.
+func (s *Section) Get(key string) (string, bool) {
+	for i := len(s.Lines) - 1; i >= 0; i-- {
+		if l := s.Lines[i]; l.Key == key {
+			_, v := l.Option()
+			return v, true
+		}
+	}
+
+	return "", false
+}
+
+// Set changes the value of the last line setting key, or appends a new line.
+func (s *Section) Set(key, value string) {
+	for i := len(s.Lines) - 1; i >= 0; i-- {
+		if l := s.Lines[i]; l.Key == key {
+			l.Value, l.Flag = value, false
+			return
+		}
+	}
+
+	s.Lines = append(s.Lines, &Line{Key: key, Value: value})
+}
+
+// Desc returns the profile-desc of a profile.
+func (s *Section) Desc() string {
+	v, _ := s.Get(KeyProfileDesc)
+
+	return v
+}
+
+// Cond returns the profile-cond of a profile, a Lua expression for auto profiles.
+func (s *Section) Cond() string {
+	v, _ := s.Get(KeyProfileCond)
+
+	return v
+}
+
+// Restore returns the profile-restore mode of a profile.
+func (s *Section) Restore() string {
+	v, _ := s.Get(KeyProfileRestore)
+
+	return v
+}
+
+// Options returns the option lines of the section, without profile-desc, profile-cond and profile-restore.
+func (s *Section) Options() []*Line {
+	var lines []*Line
+	for _, l := range s.Lines {
+		switch l.Key {
+		case "", KeyProfileDesc, KeyProfileCond, KeyProfileRestore:
+			continue
+		}
+		lines = append(lines, l)
+	}
+
+	return lines
+}
+
+// Target is what Apply sets options on, implemented by *mpv.Mpv.
+type Target interface {
+	SetOptionString(name, value string) error
+	LoadConfigFile(path string) error
+}
+
+// Apply applies the document to m, an *mpv.Mpv before Initialize. Profiles are defined first
+// by loading them with LoadConfigFile from a temporary file, since the client API cannot
+// define profiles otherwise, then the top level options are set in order with
+// SetOptionString. Include paths are resolved first, see IncludePath. It does not stop at the
+// first failing option, the returned error joins all errors.
+func (d *Document) Apply(m Target) error {
+	var errs []error
+
+	if len(d.Profiles()) > 0 {
+		if err := d.applyProfiles(m); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	for _, l := range d.Options() {
+		name, value := l.Option()
+		if name == KeyInclude {
+			value = d.IncludePath(value)
+		}
+		if err := m.SetOptionString(name, value); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (d *Document) applyProfiles(m Target) error {
+	profiles := &Document{}
+	for _, s := range d.Sections {
+		if s.Name == "" || s.Name == "default" {
+			continue
+		}
+
+		// The temporary file is elsewhere, so includes are written with absolute paths.
+		c := &Section{Name: s.Name, Comment: s.Comment, Lines: make([]*Line, len(s.Lines))}
+		for i, l := range s.Lines {
+			if l.Key == KeyInclude {
+				l = &Line{Key: l.Key, Value: d.IncludePath(l.Value), Comment: l.Comment}
+			}
+			c.Lines[i] = l
+		}
+		profiles.Sections = append(profiles.Sections, c)
+	}
+
+	f, err := os.CreateTemp("", "mpvconf-*.conf")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	_, err = profiles.WriteTo(f)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+
+	return m.LoadConfigFile(f.Name())
+}
@@ -0,0 +1,185 @@
+package mpvconf
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const sample = `# player defaults
+--vo=null
+ao = null # no sound
+keep-open
+--no-osc
+title="hello # world"
+script-opts='a="b"'
+sub-font=%5%a 'b"
+
+[fast] # speed up
+profile-desc=Fast playback
+profile-cond=speed > 1
+speed=2
+
+[default]
+volume=50
+`
+
+func TestParse(t *testing.T) {
+	doc, err := Parse(strings.NewReader(sample))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	var opts [][2]string
+	for _, l := range doc.Options() {
+		name, value := l.Option()
+		opts = append(opts, [2]string{name, value})
+	}
+	want := [][2]string{
+		{"vo", "null"},
+		{"ao", "null"},
+		{"keep-open", "yes"},
+		{"osc", "no"},
+		{"title", "hello # world"},
+		{"script-opts", `a="b"`},
+		{"sub-font", `a 'b"`},
+		{"volume", "50"},
+	}
+	if !reflect.DeepEqual(opts, want) {
+		t.Errorf("Options =\n%q\nwant\n%q", opts, want)
+	}
+
+	if got := doc.Profiles(); !reflect.DeepEqual(got, []string{"fast"}) {
+		t.Fatalf("Profiles = %q, want [fast]", got)
+	}
+	p := doc.Profile("fast")
+	if p.Desc() != "Fast playback" || p.Cond() != "speed > 1" || p.Comment != " speed up" {
+		t.Errorf("profile fast = desc %q, cond %q, comment %q", p.Desc(), p.Cond(), p.Comment)
+	}
+	if got := p.Options(); len(got) != 1 || got[0].Key != "speed" || got[0].Value != "2" {
+		t.Errorf("profile fast options = %+v", got)
+	}
+}
+
+func TestWriteRoundTrip(t *testing.T) {
+	doc, err := Parse(strings.NewReader(sample))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	doc.Top().Set("hwdec", " auto ")
+	doc.AddProfile("fast").Set("speed", "3")
+
+	out := doc.String()
+	for _, s := range []string{"# player defaults\n", "ao=null # no sound\n", "no-osc\n", "[fast] # speed up\n", "speed=3\n", `hwdec=" auto "`} {
+		if !strings.Contains(out, s) {
+			t.Errorf("output does not contain %q:\n%s", s, out)
+		}
+	}
+
+	again, err := Parse(strings.NewReader(out))
+	if err != nil {
+		t.Fatalf("Parse of written document: %v", err)
+	}
+	if !reflect.DeepEqual(again, doc) {
+		t.Errorf("round trip changed the document:\n%s\nvs\n%s", again, doc)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, src := range []string{
+		"[broken\n",
+		"title=\"open\n",
+		"sub-font=%9%short\n",
+		"vo null\n",
+		"=value\n",
+	} {
+		_, err := Parse(strings.NewReader("# ok\n" + src))
+		var perr *ParseError
+		if !errors.As(err, &perr) || perr.Line != 2 {
+			t.Errorf("Parse(%q) error = %v, want a ParseError on line 2", src, err)
+		}
+	}
+}
+
+// fakeTarget records what Apply sets, failing for options named no-such-option.
+type fakeTarget struct {
+	options [][2]string
+	configs []string
+}
+
+var errNoOption = errors.New("option not found")
+
+func (f *fakeTarget) SetOptionString(name, value string) error {
+	if name == "no-such-option" {
+		return errNoOption
+	}
+	f.options = append(f.options, [2]string{name, value})
+
+	return nil
+}
+
+func (f *fakeTarget) LoadConfigFile(path string) error {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	f.configs = append(f.configs, string(b))
+
+	return nil
+}
+
+func TestApply(t *testing.T) {
+	doc, err := Parse(strings.NewReader(sample))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	doc.Top().Set("no-such-option", "1")
+
+	var m fakeTarget
+	if err := doc.Apply(&m); !errors.Is(err, errNoOption) {
+		t.Errorf("Apply error = %v, want the error of no-such-option", err)
+	}
+
+	if len(m.configs) != 1 || !strings.Contains(m.configs[0], "[fast] # speed up\nprofile-desc=Fast playback\n") {
+		t.Errorf("profiles loaded = %q, want the fast profile", m.configs)
+	}
+	if strings.Contains(m.configs[0], "volume") {
+		t.Errorf("profiles contain the [default] section:\n%s", m.configs[0])
+	}
+	if n := len(m.options); n != 8 || m.options[n-1] != [2]string{"volume", "50"} {
+		t.Errorf("options set = %q, want 8 ending with volume=50", m.options)
+	}
+}
+
+func TestApplyRelativeInclude(t *testing.T) {
+	dir := t.TempDir()
+	name := filepath.Join(dir, "mpv.conf")
+	if err := os.WriteFile(name, []byte("include=top.conf\n[p]\ninclude=sub/p.conf\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	doc, err := ParseFile(name)
+	if err != nil {
+		t.Fatalf("ParseFile: %v", err)
+	}
+	if got, want := doc.Includes(), []string{filepath.Join(dir, "top.conf")}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Includes = %q, want %q", got, want)
+	}
+
+	var m fakeTarget
+	if err := doc.Apply(&m); err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	if want := [][2]string{{"include", filepath.Join(dir, "top.conf")}}; !reflect.DeepEqual(m.options, want) {
+		t.Errorf("options set = %q, want %q", m.options, want)
+	}
+	if want := "include=" + quote(filepath.Join(dir, "sub", "p.conf")) + "\n"; len(m.configs) != 1 || !strings.Contains(m.configs[0], want) {
+		t.Errorf("profiles loaded = %q, want %q", m.configs, want)
+	}
+	if v, _ := doc.Profile("p").Get(KeyInclude); v != "sub/p.conf" {
+		t.Errorf("Apply changed the document include to %q", v)
+	}
+}
@@ -0,0 +1,409 @@
+package mpv
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DecodeNode decodes a node value, as returned by GetProperty(FormatNode) or CommandNode,
+// into dst, which must be a non-nil pointer. Struct fields are matched by their `mpv:"name"`
+// tag, or by the field name ignoring case; a tag of "-" skips the field. time.Duration fields
+// are decoded from seconds. Unknown keys are ignored, see NodeDecoder for the strict mode.
+func DecodeNode(src any, dst any) error {
+	return (&NodeDecoder{}).Decode(src, dst)
+}
+
+// NodeDecoder decodes node values into Go values.
+type NodeDecoder struct {
+	// DisallowUnknownFields makes decoding fail on map keys that do not match any struct field.
+	DisallowUnknownFields bool
+	// Strict disables the conversions between int64 and float64 nodes and numeric fields.
+	Strict bool
+}
+
+// Decode decodes src into dst, see DecodeNode.
+func (d *NodeDecoder) Decode(src any, dst any) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return fmt.Errorf("%w: DecodeNode needs a non-nil pointer, not %T", ErrInvalidParameter, dst)
+	}
+
+	return d.decode("node", src, rv.Elem())
+}
+
+var durationType = reflect.TypeFor[time.Duration]()
+
+func (d *NodeDecoder) decode(path string, src any, dst reflect.Value) error {
+	if dst.Kind() == reflect.Pointer {
+		if src == nil {
+			dst.SetZero()
+			return nil
+		}
+		if dst.IsNil() {
+			dst.Set(reflect.New(dst.Type().Elem()))
+		}
+		return d.decode(path, src, dst.Elem())
+	}
+
+	if dst.Kind() == reflect.Interface && dst.NumMethod() == 0 {
+		if src == nil {
+			dst.SetZero()
+		} else {
+			dst.Set(reflect.ValueOf(src))
+		}
+		return nil
+	}
+
+	if src == nil {
+		dst.SetZero()
+		return nil
+	}
+
+	if dst.Type() == durationType {
+		sec, ok := d.float(src)
+		if !ok {
+			return mismatch(path, src, dst)
+		}
+		dst.SetInt(int64(sec * float64(time.Second)))
+		return nil
+	}
+
+	switch dst.Kind() {
+	case reflect.String:
+		s, ok := src.(string)
+		if !ok {
+			return mismatch(path, src, dst)
+		}
+		dst.SetString(s)
+	case reflect.Bool:
+		b, ok := src.(bool)
+		if !ok {
+			return mismatch(path, src, dst)
+		}
+		dst.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, ok := d.int(src)
+		if !ok || dst.OverflowInt(i) {
+			return mismatch(path, src, dst)
+		}
+		dst.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		i, ok := d.int(src)
+		if !ok || i < 0 || dst.OverflowUint(uint64(i)) {
+			return mismatch(path, src, dst)
+		}
+		dst.SetUint(uint64(i))
+	case reflect.Float32, reflect.Float64:
+		f, ok := d.float(src)
+		if !ok {
+			return mismatch(path, src, dst)
+		}
+		dst.SetFloat(f)
+	case reflect.Slice:
+		if b, ok := src.([]byte); ok && dst.Type().Elem().Kind() == reflect.Uint8 {
+			dst.SetBytes(append([]byte(nil), b...))
+			return nil
+		}
+		arr, ok := src.([]any)
+		if !ok {
+			return mismatch(path, src, dst)
+		}
+		out := reflect.MakeSlice(dst.Type(), len(arr), len(arr))
+		for i, v := range arr {
+			if err := d.decode(fmt.Sprintf("%s[%d]", path, i), v, out.Index(i)); err != nil {
+				return err
+			}
+		}
+		dst.Set(out)
+	case reflect.Map:
+		m, ok := src.(map[string]any)
+		if !ok || dst.Type().Key().Kind() != reflect.String {
+			return mismatch(path, src, dst)
+		}
+		out := reflect.MakeMapWithSize(dst.Type(), len(m))
+		for k, v := range m {
+			elem := reflect.New(dst.Type().Elem()).Elem()
+			if err := d.decode(path+"."+k, v, elem); err != nil {
+				return err
+			}
+			out.SetMapIndex(reflect.ValueOf(k).Convert(dst.Type().Key()), elem)
+		}
+		dst.Set(out)
+	case reflect.Struct:
+		m, ok := src.(map[string]any)
+		if !ok {
+			return mismatch(path, src, dst)
+		}
+		fields := cachedFields(dst.Type())
+		for k, v := range m {
+			f, ok := fields.lookup(k)
+			if !ok {
+				if d.DisallowUnknownFields {
+					return fmt.Errorf("%s: unknown field %q in %v", path, k, dst.Type())
+				}
+				continue
+			}
+			fv, err := fieldByIndex(dst, f.index)
+			if err != nil {
+				return err
+			}
+			if err := d.decode(path+"."+k, v, fv); err != nil {
+				return err
+			}
+		}
+	default:
+		return mismatch(path, src, dst)
+	}
+
+	return nil
+}
+
+func (d *NodeDecoder) int(src any) (int64, bool) {
+	switch v := src.(type) {
+	case int64:
+		return v, true
+	case float64:
+		if d.Strict || v != math.Trunc(v) || v < math.MinInt64 || v >= math.MaxInt64 {
+			return 0, false
+		}
+		return int64(v), true
+	}
+
+	return 0, false
+}
+
+func (d *NodeDecoder) float(src any) (float64, bool) {
+	switch v := src.(type) {
+	case float64:
+		return v, true
+	case int64:
+		if d.Strict {
+			return 0, false
+		}
+		return float64(v), true
+	}
+
+	return 0, false
+}
+
+func mismatch(path string, src any, dst reflect.Value) error {
+	return fmt.Errorf("%w: %s: cannot decode %T into %v", ErrTypeMismatch, path, src, dst.Type())
+}
+
+// fieldByIndex is like reflect.Value.FieldByIndex, but allocates nil embedded struct pointers.
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, error) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				if !v.CanSet() {
+					return reflect.Value{}, fmt.Errorf("%w: cannot set embedded pointer to unexported %v", ErrInvalidParameter, v.Type().Elem())
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+
+	return v, nil
+}
+
+// EncodeNode converts v into a node value for CommandNode and SetProperty(FormatNode).
+// Structs become maps using the same field names as DecodeNode, and the "omitempty" tag option
+// skips zero fields. Integers become int64, floats and time.Duration (in seconds) become float64.
+func EncodeNode(v any) (any, error) {
+	if v == nil {
+		return nil, nil
+	}
+
+	return encode("node", reflect.ValueOf(v))
+}
+
+func encode(path string, v reflect.Value) (any, error) {
+	if v.Type() == durationType {
+		return time.Duration(v.Int()).Seconds(), nil
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return nil, nil
+		}
+		return encode(path, v.Elem())
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return v.Bool(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u := v.Uint()
+		if u > math.MaxInt64 {
+			return nil, fmt.Errorf("%w: %s: %d overflows int64", ErrTypeMismatch, path, u)
+		}
+		return int64(u), nil
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), nil
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return nil, nil
+		}
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			b := make([]byte, v.Len())
+			reflect.Copy(reflect.ValueOf(b), v)
+			return b, nil
+		}
+		out := make([]any, v.Len())
+		for i := range out {
+			e, err := encode(fmt.Sprintf("%s[%d]", path, i), v.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			out[i] = e
+		}
+		return out, nil
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("%w: %s: map key %v is not a string", ErrTypeMismatch, path, v.Type().Key())
+		}
+		if v.IsNil() {
+			return nil, nil
+		}
+		out := make(map[string]any, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			k := iter.Key().String()
+			e, err := encode(path+"."+k, iter.Value())
+			if err != nil {
+				return nil, err
+			}
+			out[k] = e
+		}
+		return out, nil
+	case reflect.Struct:
+		out := map[string]any{}
+		for _, f := range cachedFields(v.Type()).list {
+			fv, ok := fieldByIndexNoAlloc(v, f.index)
+			if !ok || (f.omitEmpty && fv.IsZero()) {
+				continue
+			}
+			e, err := encode(path+"."+f.name, fv)
+			if err != nil {
+				return nil, err
+			}
+			out[f.name] = e
+		}
+		return out, nil
+	default:
+		return nil, fmt.Errorf("%w: %s: cannot encode %v", ErrTypeMismatch, path, v.Type())
+	}
+}
+
+// fieldByIndexNoAlloc is like reflect.Value.FieldByIndex, but reports false for nil embedded pointers.
+func fieldByIndexNoAlloc(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+
+	return v, true
+}
+
+// structField describes how a struct field maps to a node map key.
+type structField struct {
+	name      string
+	index     []int
+	omitEmpty bool
+}
+
+type structFields struct {
+	list   []structField
+	byName map[string]int
+}
+
+// lookup finds the field for a map key, preferring an exact match over a case-insensitive one.
+func (sf *structFields) lookup(key string) (structField, bool) {
+	if i, ok := sf.byName[key]; ok {
+		return sf.list[i], true
+	}
+	for _, f := range sf.list {
+		if strings.EqualFold(f.name, key) {
+			return f, true
+		}
+	}
+
+	return structField{}, false
+}
+
+var fieldCache sync.Map // map[reflect.Type]*structFields
+
+func cachedFields(t reflect.Type) *structFields {
+	if f, ok := fieldCache.Load(t); ok {
+		return f.(*structFields)
+	}
+
+	sf := &structFields{byName: map[string]int{}}
+	collectFields(t, nil, sf)
+	f, _ := fieldCache.LoadOrStore(t, sf)
+
+	return f.(*structFields)
+}
+
+// collectFields adds the fields of t, flattening untagged embedded structs like encoding/json.
+// Fields of the outer struct win over promoted ones with the same name.
+func collectFields(t reflect.Type, index []int, sf *structFields) {
+	var embedded []reflect.StructField
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("mpv")
+		if tag == "-" {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(tag, ",")
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				embedded = append(embedded, f)
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		if _, dup := sf.byName[name]; dup {
+			continue
+		}
+
+		sf.byName[name] = len(sf.list)
+		sf.list = append(sf.list, structField{
+			name:      name,
+			index:     append(append([]int(nil), index...), i),
+			omitEmpty: opts == "omitempty",
+		})
+	}
+
+	for _, f := range embedded {
+		ft := f.Type
+		if ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		collectFields(ft, append(append([]int(nil), index...), f.Index[0]), sf)
+	}
+}
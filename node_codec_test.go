@@ -0,0 +1,154 @@
+package mpv
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type codecBase struct {
+	ID int64 `mpv:"id"`
+}
+
+type codecInner struct {
+	Codec string `mpv:"codec"`
+	Rate  *int   `mpv:"rate,omitempty"`
+}
+
+type codecOuter struct {
+	codecBase
+	Title    string            `mpv:"title"`
+	Default  bool              `mpv:"default"`
+	Length   time.Duration     `mpv:"length"`
+	Ratio    float64           `mpv:"ratio"`
+	Tags     []string          `mpv:"tags"`
+	Meta     map[string]string `mpv:"meta"`
+	Inner    *codecInner       `mpv:"inner"`
+	Items    []codecInner      `mpv:"items"`
+	Raw      any               `mpv:"raw"`
+	Data     []byte            `mpv:"data"`
+	Selected bool
+	Ignored  string `mpv:"-"`
+}
+
+func TestDecodeNode(t *testing.T) {
+	rate := 48000
+	src := map[string]any{
+		"id":       int64(2),
+		"title":    "main",
+		"default":  true,
+		"length":   1.5,
+		"ratio":    int64(2),
+		"tags":     []any{"a", "b"},
+		"meta":     map[string]any{"k": "v"},
+		"inner":    map[string]any{"codec": "mp2", "rate": int64(48000)},
+		"items":    []any{map[string]any{"codec": "x"}},
+		"raw":      []any{int64(1)},
+		"data":     []byte{1, 2},
+		"selected": true,
+		"Ignored":  "no",
+		"unknown":  "skipped",
+	}
+
+	var got codecOuter
+	if err := DecodeNode(src, &got); err != nil {
+		t.Fatalf("DecodeNode: %v", err)
+	}
+
+	want := codecOuter{
+		codecBase: codecBase{ID: 2},
+		Title:     "main",
+		Default:   true,
+		Length:    1500 * time.Millisecond,
+		Ratio:     2,
+		Tags:      []string{"a", "b"},
+		Meta:      map[string]string{"k": "v"},
+		Inner:     &codecInner{Codec: "mp2", Rate: &rate},
+		Items:     []codecInner{{Codec: "x"}},
+		Raw:       []any{int64(1)},
+		Data:      []byte{1, 2},
+		Selected:  true,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("DecodeNode =\n%#v\nwant\n%#v", got, want)
+	}
+
+	strict := &NodeDecoder{DisallowUnknownFields: true}
+	if err := strict.Decode(src, &got); err == nil {
+		t.Fatal("DisallowUnknownFields accepted an unknown key")
+	}
+
+	var ratio struct {
+		Ratio float64 `mpv:"ratio"`
+	}
+	if err := (&NodeDecoder{Strict: true}).Decode(map[string]any{"ratio": int64(2)}, &ratio); !errors.Is(err, ErrTypeMismatch) {
+		t.Fatalf("Strict int64 into float64 = %v, want ErrTypeMismatch", err)
+	}
+
+	var title struct {
+		Title int `mpv:"title"`
+	}
+	if err := DecodeNode(src, &title); !errors.Is(err, ErrTypeMismatch) {
+		t.Fatalf("string into int = %v, want ErrTypeMismatch", err)
+	}
+
+	if err := DecodeNode(src, got); !errors.Is(err, ErrInvalidParameter) {
+		t.Fatalf("non-pointer dst = %v, want ErrInvalidParameter", err)
+	}
+}
+
+func TestEncodeNode(t *testing.T) {
+	in := codecOuter{
+		codecBase: codecBase{ID: 3},
+		Title:     "t",
+		Length:    2 * time.Second,
+		Tags:      []string{"x"},
+		Meta:      map[string]string{"k": "v"},
+		Inner:     &codecInner{Codec: "c"},
+		Ignored:   "no",
+	}
+
+	got, err := EncodeNode(in)
+	if err != nil {
+		t.Fatalf("EncodeNode: %v", err)
+	}
+
+	want := map[string]any{
+		"id":       int64(3),
+		"title":    "t",
+		"default":  false,
+		"length":   2.0,
+		"ratio":    0.0,
+		"tags":     []any{"x"},
+		"meta":     map[string]any{"k": "v"},
+		"inner":    map[string]any{"codec": "c"},
+		"items":    nil,
+		"raw":      nil,
+		"data":     nil,
+		"Selected": false,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("EncodeNode =\n%#v\nwant\n%#v", got, want)
+	}
+
+	var back codecOuter
+	if err := DecodeNode(got, &back); err != nil {
+		t.Fatalf("DecodeNode of encoded value: %v", err)
+	}
+	in.Ignored = ""
+	if !reflect.DeepEqual(back, in) {
+		t.Fatalf("round-trip =\n%#v\nwant\n%#v", back, in)
+	}
+
+	// The encoded value must be accepted by the C node conversion.
+	p, cleanup := goToNode(got)
+	defer cleanup()
+	if !reflect.DeepEqual(nodeToGo(p), got) {
+		t.Fatal("encoded value did not survive goToNode")
+	}
+
+	if _, err := EncodeNode(map[int]string{1: "a"}); !errors.Is(err, ErrTypeMismatch) {
+		t.Fatalf("non-string map key = %v, want ErrTypeMismatch", err)
+	}
+}
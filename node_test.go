@@ -51,7 +51,8 @@ func TestNodeTrackList(t *testing.T) {
 			t.Fatal("file did not load")
 		}
 		if e.EventID == EventEnd {
-			t.Fatalf("playback ended before load: %v", e.EndFile().Reason)
+			ef, _ := e.EndFile()
+			t.Fatalf("playback ended before load: %v", ef.Reason)
 		}
 	}
 
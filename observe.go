@@ -0,0 +1,38 @@
+package mpv
+
+import (
+	"sync"
+)
+
+// Observe calls fn whenever the given property changes, starting with its current value.
+// Each call allocates its own reply userdata, so several observers of the same property do
+// not interfere. fn runs on the goroutine calling WaitEvent (or Events) and must not wait for events.
+func (m *Mpv) Observe(name string, format Format, fn func(EventProperty)) (unsubscribe func(), err error) {
+	id := m.state.nextID()
+
+	m.state.mu.Lock()
+	m.state.observers[id] = fn
+	m.state.mu.Unlock()
+
+	err = m.observeID(id, name, format)
+	if err != nil {
+		m.state.mu.Lock()
+		delete(m.state.observers, id)
+		m.state.mu.Unlock()
+
+		return nil, err
+	}
+
+	var once sync.Once
+	unsubscribe = func() {
+		once.Do(func() {
+			m.state.mu.Lock()
+			delete(m.state.observers, id)
+			m.state.mu.Unlock()
+
+			_ = m.unobserveID(id)
+		})
+	}
+
+	return unsubscribe, nil
+}
@@ -0,0 +1,65 @@
+package mpv
+
+import (
+	"testing"
+)
+
+func TestObserve(t *testing.T) {
+	m := newHeadless(t)
+
+	var first, second []int
+	unsubFirst, err := m.Observe("pause", FormatFlag, func(p EventProperty) {
+		first = append(first, p.Data.(int))
+	})
+	if err != nil {
+		t.Fatalf("Observe: %v", err)
+	}
+	defer unsubFirst()
+
+	unsubSecond, err := m.Observe("pause", FormatFlag, func(p EventProperty) {
+		if p.Name != "pause" {
+			t.Errorf("property name = %q, want pause", p.Name)
+		}
+		second = append(second, p.Data.(int))
+	})
+	if err != nil {
+		t.Fatalf("Observe: %v", err)
+	}
+
+	pump := func(cond func() bool) {
+		t.Helper()
+		for i := 0; i < 100 && !cond(); i++ {
+			if e := m.WaitEvent(1); e.EventID == EventNone {
+				break
+			}
+		}
+		if !cond() {
+			t.Fatalf("timed out, first = %v, second = %v", first, second)
+		}
+	}
+
+	// Both observers get the initial value.
+	pump(func() bool { return len(first) == 1 && len(second) == 1 })
+
+	if err := m.SetProperty("pause", FormatFlag, true); err != nil {
+		t.Fatal(err)
+	}
+	pump(func() bool { return len(first) == 2 && len(second) == 2 })
+	if first[1] != 1 || second[1] != 1 {
+		t.Fatalf("pause change = %v, %v, want 1", first, second)
+	}
+
+	unsubSecond()
+	unsubSecond()
+
+	if err := m.SetProperty("pause", FormatFlag, false); err != nil {
+		t.Fatal(err)
+	}
+	pump(func() bool { return len(first) == 3 })
+	if first[2] != 0 {
+		t.Fatalf("pause change = %v, want 0", first)
+	}
+	if len(second) != 2 {
+		t.Fatalf("unsubscribed observer was called: %v", second)
+	}
+}
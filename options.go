@@ -0,0 +1,157 @@
+package mpv
+
+import (
+	"errors"
+	"strconv"
+)
+
+// Options holds commonly used options that must be set before Initialize. Zero values,
+// empty strings and nil pointers leave the mpv default in place; use Ptr for the pointer
+// fields. Options that have no field here can be added with Set or Raw.
+type Options struct {
+	// VO and AO select the video and audio outputs, e.g. "libmpv", "gpu" or "null".
+	VO string
+	AO string
+	// HWDec selects hardware decoding, e.g. "auto-safe" or "no".
+	HWDec string
+	// Wid embeds the video window into the given native window ID.
+	Wid int64
+
+	// Config enables loading mpv.conf and the other user config files from ConfigDir.
+	Config    *bool
+	ConfigDir string
+	// Profile applies the named profiles, separated by commas.
+	Profile string
+	// LoadScripts enables loading of the user and builtin scripts.
+	LoadScripts *bool
+	// OSC enables the on screen controller.
+	OSC *bool
+	// YTDL enables the youtube-dl/yt-dlp hook.
+	YTDL *bool
+
+	// InputDefaultBindings enables mpv's default key bindings.
+	InputDefaultBindings *bool
+	// InputVOKeyboard enables keyboard input on the video window.
+	InputVOKeyboard *bool
+	// InputCursor enables mouse input on the video window.
+	InputCursor *bool
+
+	// Terminal enables terminal input and output, MsgLevel sets the terminal log levels, e.g. "all=warn".
+	Terminal *bool
+	MsgLevel string
+
+	// Idle and KeepOpen take the IdleChoice and KeepOpenChoice values, e.g. IdleYes.
+	Idle     IdleChoice
+	KeepOpen KeepOpenChoice
+	Pause    *bool
+	// Volume is the initial volume, 100 being the source volume.
+	Volume     *float64
+	Mute       *bool
+	Fullscreen *bool
+
+	// Raw holds other options, applied in order after the typed fields.
+	Raw []RawOption
+}
+
+// RawOption is an option name and value as passed to SetOptionString.
+type RawOption struct {
+	Name  string
+	Value string
+}
+
+// Ptr returns a pointer to v, for the optional fields of Options.
+func Ptr[T any](v T) *T {
+	return &v
+}
+
+// Set adds a raw option and returns o, so calls can be chained.
+func (o *Options) Set(name, value string) *Options {
+	o.Raw = append(o.Raw, RawOption{Name: name, Value: value})
+
+	return o
+}
+
+// List returns the options that are set, in the order Apply sets them.
+func (o *Options) List() []RawOption {
+	var list []RawOption
+
+	str := func(name, v string) {
+		if v != "" {
+			list = append(list, RawOption{name, v})
+		}
+	}
+	flag := func(name string, v *bool) {
+		if v != nil {
+			list = append(list, RawOption{name, yesNo(*v)})
+		}
+	}
+
+	str("vo", o.VO)
+	str("ao", o.AO)
+	str("hwdec", o.HWDec)
+	if o.Wid != 0 {
+		list = append(list, RawOption{"wid", strconv.FormatInt(o.Wid, 10)})
+	}
+	flag("config", o.Config)
+	str("config-dir", o.ConfigDir)
+	str("profile", o.Profile)
+	flag("load-scripts", o.LoadScripts)
+	flag("osc", o.OSC)
+	flag("ytdl", o.YTDL)
+	flag("input-default-bindings", o.InputDefaultBindings)
+	flag("input-vo-keyboard", o.InputVOKeyboard)
+	flag("input-cursor", o.InputCursor)
+	flag("terminal", o.Terminal)
+	str("msg-level", o.MsgLevel)
+	str("idle", string(o.Idle))
+	str("keep-open", string(o.KeepOpen))
+	flag("pause", o.Pause)
+	if o.Volume != nil {
+		list = append(list, RawOption{"volume", strconv.FormatFloat(*o.Volume, 'g', -1, 64)})
+	}
+	flag("mute", o.Mute)
+	flag("fullscreen", o.Fullscreen)
+
+	return append(list, o.Raw...)
+}
+
+// Apply sets all options on m with SetOptionString. It does not stop at the first failure,
+// the returned error joins the errors of all options that could not be set.
+func (o *Options) Apply(m *Mpv) error {
+	var errs []error
+	for _, opt := range o.List() {
+		if err := m.SetOptionString(opt.Name, opt.Value); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// NewWithOptions creates a new mpv instance, applies opts and initializes it.
+// The instance is destroyed if any option fails or initialization fails.
+func NewWithOptions(opts *Options) (*Mpv, error) {
+	m := New()
+
+	if opts != nil {
+		if err := opts.Apply(m); err != nil {
+			m.TerminateDestroy()
+			return nil, err
+		}
+	}
+
+	if err := m.Initialize(); err != nil {
+		m.TerminateDestroy()
+		return nil, err
+	}
+
+	return m, nil
+}
+
+func yesNo(v bool) string {
+	if v {
+		return "yes"
+	}
+
+	return "no"
+}
@@ -0,0 +1,52 @@
+package mpv
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestOptionsList(t *testing.T) {
+	opts := &Options{
+		VO:                   "null",
+		InputDefaultBindings: Ptr(false),
+		Volume:               Ptr(50.5),
+	}
+	opts.Set("cache", "no").Set("demuxer-max-bytes", "1MiB")
+
+	want := []RawOption{
+		{"vo", "null"},
+		{"input-default-bindings", "no"},
+		{"volume", "50.5"},
+		{"cache", "no"},
+		{"demuxer-max-bytes", "1MiB"},
+	}
+	if got := opts.List(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("List = %v, want %v", got, want)
+	}
+}
+
+func TestNewWithOptions(t *testing.T) {
+	m, err := NewWithOptions(&Options{VO: "null", AO: "null", Pause: Ptr(true), Idle: IdleYes})
+	if err != nil {
+		t.Fatalf("NewWithOptions: %v", err)
+	}
+	defer m.TerminateDestroy()
+
+	if v, err := Get[bool](m, "pause"); err != nil || !v {
+		t.Errorf("pause = %v, %v, want true", v, err)
+	}
+
+	opts := &Options{VO: "null", AO: "null"}
+	opts.Set("no-such-option", "1").Set("volume", "loud")
+
+	_, err = NewWithOptions(opts)
+	if !errors.Is(err, ErrOptionNotFound) || !strings.Contains(err.Error(), `"volume"`) {
+		t.Fatalf("NewWithOptions error = %v, want errors for no-such-option and volume", err)
+	}
+	var merr *Error
+	if !errors.As(err, &merr) || merr.Name != "no-such-option" {
+		t.Errorf("first error = %#v, want no-such-option", merr)
+	}
+}
@@ -0,0 +1,218 @@
+package mpv
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+// Payload is the decoded data of an event, as returned by Event.Decode. It is one of
+// PropertyChangeEvent, GetPropertyReplyEvent, SetPropertyReplyEvent, CommandReplyEvent,
+// StartFileEvent, EndFileEvent, LogMessageEvent, ClientMessageEvent, HookEvent or SimpleEvent.
+// All payloads are copied into Go memory and stay valid after the next WaitEvent.
+type Payload interface {
+	EventID() EventID
+	payload()
+}
+
+// PropertyChangeEvent is the payload of EventPropertyChange.
+type PropertyChangeEvent struct {
+	EventProperty
+}
+
+// GetPropertyReplyEvent is the payload of EventGetPropertyReply.
+type GetPropertyReplyEvent struct {
+	EventProperty
+}
+
+// SetPropertyReplyEvent is the payload of EventSetPropertyReply, the result is in Event.Error.
+type SetPropertyReplyEvent struct{}
+
+// CommandReplyEvent is the payload of EventCommandReply.
+type CommandReplyEvent struct {
+	Result any
+}
+
+// StartFileEvent is the payload of EventStart.
+type StartFileEvent struct {
+	EventStartFile
+}
+
+// EndFileEvent is the payload of EventEnd.
+type EndFileEvent struct {
+	EventEndFile
+}
+
+// LogMessageEvent is the payload of EventLogMsg.
+type LogMessageEvent struct {
+	EventLogMessage
+}
+
+// ClientMessageEvent is the payload of EventClientMessage.
+type ClientMessageEvent struct {
+	Args []string
+}
+
+// HookEvent is the payload of EventHook.
+type HookEvent struct {
+	Hook
+}
+
+// SimpleEvent is the payload of events that carry no data, e.g. EventShutdown or EventFileLoaded.
+type SimpleEvent struct {
+	ID EventID
+}
+
+// EventID implements Payload.
+func (PropertyChangeEvent) EventID() EventID { return EventPropertyChange }
+
+// EventID implements Payload.
+func (GetPropertyReplyEvent) EventID() EventID { return EventGetPropertyReply }
+
+// EventID implements Payload.
+func (SetPropertyReplyEvent) EventID() EventID { return EventSetPropertyReply }
+
+// EventID implements Payload.
+func (CommandReplyEvent) EventID() EventID { return EventCommandReply }
+
+// EventID implements Payload.
+func (StartFileEvent) EventID() EventID { return EventStart }
+
+// EventID implements Payload.
+func (EndFileEvent) EventID() EventID { return EventEnd }
+
+// EventID implements Payload.
+func (LogMessageEvent) EventID() EventID { return EventLogMsg }
+
+// EventID implements Payload.
+func (ClientMessageEvent) EventID() EventID { return EventClientMessage }
+
+// EventID implements Payload.
+func (HookEvent) EventID() EventID { return EventHook }
+
+// EventID implements Payload.
+func (s SimpleEvent) EventID() EventID { return s.ID }
+
+func (PropertyChangeEvent) payload()   {}
+func (GetPropertyReplyEvent) payload() {}
+func (SetPropertyReplyEvent) payload() {}
+func (CommandReplyEvent) payload()     {}
+func (StartFileEvent) payload()        {}
+func (EndFileEvent) payload()          {}
+func (LogMessageEvent) payload()       {}
+func (ClientMessageEvent) payload()    {}
+func (HookEvent) payload()             {}
+func (SimpleEvent) payload()           {}
+
+// Decode returns the payload of the event, copied into Go memory. Use a type switch on
+// the result instead of reading Data directly. It fails with ErrInvalidParameter for
+// unknown event IDs, and for events that should carry data but have none.
+func (e *Event) Decode() (Payload, error) {
+	if e.decodeErr != nil {
+		return nil, e.decodeErr
+	}
+	if e.payload != nil {
+		return e.payload, nil
+	}
+
+	switch e.EventID {
+	case EventNone, EventShutdown, EventFileLoaded, EventVideoReconfig, EventAudioReconfig,
+		EventSeek, EventPlaybackRestart, EventQueueOverflow:
+		return SimpleEvent{ID: e.EventID}, nil
+	case EventSetPropertyReply:
+		return SetPropertyReplyEvent{}, nil
+	}
+
+	if _, ok := eventMap[e.EventID]; !ok {
+		return nil, fmt.Errorf("%w: unknown event %d", ErrInvalidParameter, uint32(e.EventID))
+	}
+	if e.Data == nil {
+		return nil, fmt.Errorf("%w: %s event has no data", ErrInvalidParameter, e.EventID)
+	}
+
+	switch e.EventID {
+	case EventLogMsg:
+		return LogMessageEvent{decodeLogMessage(e.Data)}, nil
+	case EventPropertyChange:
+		return PropertyChangeEvent{decodeProperty(e.Data)}, nil
+	case EventGetPropertyReply:
+		return GetPropertyReplyEvent{decodeProperty(e.Data)}, nil
+	case EventCommandReply:
+		return CommandReplyEvent{Result: nodeToGo(e.Data)}, nil
+	case EventStart:
+		return StartFileEvent{decodeStartFile(e.Data)}, nil
+	case EventEnd:
+		return EndFileEvent{decodeEndFile(e.Data)}, nil
+	case EventClientMessage:
+		return ClientMessageEvent{Args: decodeClientMessage(e.Data)}, nil
+	case EventHook:
+		s := (*eventHook)(e.Data)
+		return HookEvent{Hook{Name: toStr(s.Name), ID: s.ID}}, nil
+	}
+
+	return nil, fmt.Errorf("%w: unknown event %d", ErrInvalidParameter, uint32(e.EventID))
+}
+
+func decodeLogMessage(data unsafe.Pointer) EventLogMessage {
+	s := (*eventLogMessage)(data)
+
+	return EventLogMessage{
+		Prefix:   toStr(s.Prefix),
+		Level:    toStr(s.Level),
+		Text:     toStr(s.Text),
+		LogLevel: s.LogLevel,
+	}
+}
+
+func decodeProperty(data unsafe.Pointer) EventProperty {
+	s := (*eventProperty)(data)
+	ep := EventProperty{Name: toStr(s.Name), Format: Format(s.Format)}
+
+	if s.Data == nil {
+		return ep
+	}
+
+	switch ep.Format {
+	case FormatString, FormatOsdString:
+		ep.Data = toStr(*(*unsafe.Pointer)(s.Data))
+	case FormatFlag:
+		ep.Data = int(*(*int32)(s.Data))
+	case FormatInt64:
+		ep.Data = *(*int64)(s.Data)
+	case FormatDouble:
+		ep.Data = *(*float64)(s.Data)
+	case FormatNode:
+		ep.Data = nodeToGo(s.Data)
+	}
+
+	return ep
+}
+
+func decodeStartFile(data unsafe.Pointer) EventStartFile {
+	return EventStartFile{EntryID: (*EventStartFile)(data).EntryID}
+}
+
+func decodeEndFile(data unsafe.Pointer) EventEndFile {
+	s := (*eventEndFile)(data)
+
+	return EventEndFile{
+		Reason:           Reason(s.Reason),
+		Error:            newError(int(s.Error)),
+		EntryID:          s.EntryID,
+		InsertID:         s.InsertID,
+		InsertNumEntries: s.InsertNumEntries,
+	}
+}
+
+func decodeClientMessage(data unsafe.Pointer) []string {
+	s := (*eventClientMessage)(data)
+	out := make([]string, s.NumArgs)
+
+	if s.NumArgs > 0 {
+		args := unsafe.Slice((*unsafe.Pointer)(s.Args), int(s.NumArgs))
+		for i := range args {
+			out[i] = toStr(args[i])
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,237 @@
+package mpv
+
+//go:generate go run -tags nolibmpv ./cmd/mpvgen -from cmd/mpvgen/testdata/snapshot.json -o properties_gen.go
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// TrackID is the value of the aid, vid and sid properties: the ID of a track in track-list,
+// or one of TrackIDNo and TrackIDAuto.
+type TrackID int64
+
+// Special track IDs.
+const (
+	// TrackIDAuto lets mpv's track selection pick the track.
+	TrackIDAuto TrackID = -1
+	// TrackIDNo selects no track of the type.
+	TrackIDNo TrackID = 0
+)
+
+// String returns the property value of the track ID, "auto" and "no" for the special IDs.
+func (t TrackID) String() string {
+	switch {
+	case t == TrackIDNo:
+		return "no"
+	case t < 0:
+		return "auto"
+	}
+
+	return strconv.FormatInt(int64(t), 10)
+}
+
+// parseTrackID parses the string value of a track property.
+func parseTrackID(s string) (TrackID, error) {
+	switch s {
+	case "no":
+		return TrackIDNo, nil
+	case "auto":
+		return TrackIDAuto, nil
+	}
+
+	id, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("%w: track ID %q", ErrPropertyFormat, s)
+	}
+
+	return TrackID(id), nil
+}
+
+// Track is an entry of the track-list property.
+type Track struct {
+	ID                  int64    `mpv:"id"`
+	Type                string   `mpv:"type"`
+	SrcID               int64    `mpv:"src-id"`
+	Title               string   `mpv:"title"`
+	Lang                string   `mpv:"lang"`
+	Image               bool     `mpv:"image"`
+	AlbumArt            bool     `mpv:"albumart"`
+	Default             bool     `mpv:"default"`
+	Forced              bool     `mpv:"forced"`
+	Dependent           bool     `mpv:"dependent"`
+	VisualImpaired      bool     `mpv:"visual-impaired"`
+	HearingImpaired     bool     `mpv:"hearing-impaired"`
+	External            bool     `mpv:"external"`
+	ExternalFilename    string   `mpv:"external-filename"`
+	Selected            bool     `mpv:"selected"`
+	MainSelection       int64    `mpv:"main-selection"`
+	FFIndex             int64    `mpv:"ff-index"`
+	Codec               string   `mpv:"codec"`
+	CodecDesc           string   `mpv:"codec-desc"`
+	CodecProfile        string   `mpv:"codec-profile"`
+	Decoder             string   `mpv:"decoder"`
+	DecoderDesc         string   `mpv:"decoder-desc"`
+	FormatName          string   `mpv:"format-name"`
+	DemuxW              int64    `mpv:"demux-w"`
+	DemuxH              int64    `mpv:"demux-h"`
+	DemuxChannelCount   int64    `mpv:"demux-channel-count"`
+	DemuxChannels       string   `mpv:"demux-channels"`
+	DemuxSamplerate     int64    `mpv:"demux-samplerate"`
+	DemuxFPS            float64  `mpv:"demux-fps"`
+	DemuxBitrate        int64    `mpv:"demux-bitrate"`
+	DemuxRotation       int64    `mpv:"demux-rotation"`
+	DemuxPAR            float64  `mpv:"demux-par"`
+	AudioChannels       int64    `mpv:"audio-channels"`
+	ReplayGainTrackPeak float64  `mpv:"replaygain-track-peak"`
+	ReplayGainTrackGain float64  `mpv:"replaygain-track-gain"`
+	ReplayGainAlbumPeak float64  `mpv:"replaygain-album-peak"`
+	ReplayGainAlbumGain float64  `mpv:"replaygain-album-gain"`
+	Metadata            Metadata `mpv:"metadata"`
+}
+
+// Chapter is an entry of the chapter-list property.
+type Chapter struct {
+	Title string        `mpv:"title"`
+	Time  time.Duration `mpv:"time"`
+}
+
+// PlaylistEntry is an entry of the playlist property.
+type PlaylistEntry struct {
+	ID           int64  `mpv:"id"`
+	Filename     string `mpv:"filename"`
+	Title        string `mpv:"title"`
+	Current      bool   `mpv:"current"`
+	Playing      bool   `mpv:"playing"`
+	PlaylistPath string `mpv:"playlist-path"`
+}
+
+// Metadata is the tag map of the metadata property, keyed by the tag names of the file.
+type Metadata map[string]string
+
+// VideoParams is the value of the video-params and video-out-params properties.
+type VideoParams struct {
+	PixelFormat    string  `mpv:"pixelformat"`
+	HwPixelFormat  string  `mpv:"hw-pixelformat"`
+	AverageBpp     int64   `mpv:"average-bpp"`
+	W              int64   `mpv:"w"`
+	H              int64   `mpv:"h"`
+	DW             int64   `mpv:"dw"`
+	DH             int64   `mpv:"dh"`
+	CropX          int64   `mpv:"crop-x"`
+	CropY          int64   `mpv:"crop-y"`
+	CropW          int64   `mpv:"crop-w"`
+	CropH          int64   `mpv:"crop-h"`
+	Aspect         float64 `mpv:"aspect"`
+	PAR            float64 `mpv:"par"`
+	SARName        string  `mpv:"sar-name"`
+	ColorMatrix    string  `mpv:"colormatrix"`
+	ColorLevels    string  `mpv:"colorlevels"`
+	Primaries      string  `mpv:"primaries"`
+	Gamma          string  `mpv:"gamma"`
+	SigPeak        float64 `mpv:"sig-peak"`
+	Light          string  `mpv:"light"`
+	ChromaLocation string  `mpv:"chroma-location"`
+	Rotate         int64   `mpv:"rotate"`
+	StereoIn       string  `mpv:"stereo-in"`
+	Alpha          string  `mpv:"alpha"`
+}
+
+// AudioParams is the value of the audio-params and audio-out-params properties.
+type AudioParams struct {
+	Format       string `mpv:"format"`
+	Samplerate   int64  `mpv:"samplerate"`
+	Channels     string `mpv:"channels"`
+	ChannelCount int64  `mpv:"channel-count"`
+	HrChannels   string `mpv:"hr-channels"`
+}
+
+// SeekableRange is a cached range of DemuxerCacheState.
+type SeekableRange struct {
+	Start time.Duration `mpv:"start"`
+	End   time.Duration `mpv:"end"`
+}
+
+// DemuxerCacheState is the value of the demuxer-cache-state property.
+type DemuxerCacheState struct {
+	SeekableRanges []SeekableRange `mpv:"seekable-ranges"`
+	BOFCached      bool            `mpv:"bof-cached"`
+	EOFCached      bool            `mpv:"eof-cached"`
+	CacheEnd       time.Duration   `mpv:"cache-end"`
+	ReaderPTS      time.Duration   `mpv:"reader-pts"`
+	CacheDuration  time.Duration   `mpv:"cache-duration"`
+	EOF            bool            `mpv:"eof"`
+	Underrun       bool            `mpv:"underrun"`
+	Idle           bool            `mpv:"idle"`
+	TotalBytes     int64           `mpv:"total-bytes"`
+	FwBytes        int64           `mpv:"fw-bytes"`
+	FileCacheBytes int64           `mpv:"file-cache-bytes"`
+	RawInputRate   int64           `mpv:"raw-input-rate"`
+}
+
+// getNode gets the property as FormatNode and decodes it into dst.
+func (m *Mpv) getNode(name string, dst any) error {
+	v, err := m.GetProperty(name, FormatNode)
+	if err != nil {
+		return err
+	}
+
+	return DecodeNode(v, dst)
+}
+
+// Tracks returns the track-list property.
+func (m *Mpv) Tracks() ([]Track, error) {
+	var tracks []Track
+	err := m.getNode("track-list", &tracks)
+
+	return tracks, err
+}
+
+// Chapters returns the chapter-list property.
+func (m *Mpv) Chapters() ([]Chapter, error) {
+	var chapters []Chapter
+	err := m.getNode("chapter-list", &chapters)
+
+	return chapters, err
+}
+
+// Playlist returns the playlist property.
+func (m *Mpv) Playlist() ([]PlaylistEntry, error) {
+	var entries []PlaylistEntry
+	err := m.getNode("playlist", &entries)
+
+	return entries, err
+}
+
+// Metadata returns the metadata property of the current file.
+func (m *Mpv) Metadata() (Metadata, error) {
+	var md Metadata
+	err := m.getNode("metadata", &md)
+
+	return md, err
+}
+
+// VideoParams returns the video-params property.
+func (m *Mpv) VideoParams() (VideoParams, error) {
+	var vp VideoParams
+	err := m.getNode("video-params", &vp)
+
+	return vp, err
+}
+
+// AudioParams returns the audio-params property.
+func (m *Mpv) AudioParams() (AudioParams, error) {
+	var ap AudioParams
+	err := m.getNode("audio-params", &ap)
+
+	return ap, err
+}
+
+// DemuxerCacheState returns the demuxer-cache-state property.
+func (m *Mpv) DemuxerCacheState() (DemuxerCacheState, error) {
+	var cs DemuxerCacheState
+	err := m.getNode("demuxer-cache-state", &cs)
+
+	return cs, err
+}
@@ -0,0 +1,1013 @@
+// Code generated by mpvgen from mpv v0.39.0; DO NOT EDIT.
+
+package mpv
+
+import "time"
+
+// AID returns the aid property.
+//
+// Default: "auto".
+// Range: 0 to 8190.
+func (m *Mpv) AID() (TrackID, error) {
+	v, err := Get[string](m, "aid")
+	if err != nil {
+		return 0, err
+	}
+
+	return parseTrackID(v)
+}
+
+// SetAID sets the aid property.
+func (m *Mpv) SetAID(v TrackID) error {
+	return Set(m, "aid", v.String())
+}
+
+// AVSync returns the avsync property.
+func (m *Mpv) AVSync() (float64, error) {
+	return Get[float64](m, "avsync")
+}
+
+// AudioDelay returns the audio-delay property.
+//
+// Default: 0.
+func (m *Mpv) AudioDelay() (float64, error) {
+	return Get[float64](m, "audio-delay")
+}
+
+// SetAudioDelay sets the audio-delay property.
+func (m *Mpv) SetAudioDelay(v float64) error {
+	return Set(m, "audio-delay", v)
+}
+
+// AudioDevice returns the audio-device property.
+//
+// Default: "auto".
+func (m *Mpv) AudioDevice() (string, error) {
+	return Get[string](m, "audio-device")
+}
+
+// SetAudioDevice sets the audio-device property.
+func (m *Mpv) SetAudioDevice(v string) error {
+	return Set(m, "audio-device", v)
+}
+
+// AudioPitchCorrection returns the audio-pitch-correction property.
+//
+// Default: yes.
+func (m *Mpv) AudioPitchCorrection() (bool, error) {
+	return Get[bool](m, "audio-pitch-correction")
+}
+
+// SetAudioPitchCorrection sets the audio-pitch-correction property.
+func (m *Mpv) SetAudioPitchCorrection(v bool) error {
+	return Set(m, "audio-pitch-correction", v)
+}
+
+// Border returns the border property.
+//
+// Default: yes.
+func (m *Mpv) Border() (bool, error) {
+	return Get[bool](m, "border")
+}
+
+// SetBorder sets the border property.
+func (m *Mpv) SetBorder(v bool) error {
+	return Set(m, "border", v)
+}
+
+// Brightness returns the brightness property.
+//
+// Default: 0.
+// Range: -100 to 100.
+func (m *Mpv) Brightness() (float64, error) {
+	return Get[float64](m, "brightness")
+}
+
+// SetBrightness sets the brightness property.
+func (m *Mpv) SetBrightness(v float64) error {
+	return Set(m, "brightness", v)
+}
+
+// CacheChoice is a value of the cache option.
+type CacheChoice string
+
+// CacheChoice values.
+const (
+	CacheNo   CacheChoice = "no"
+	CacheAuto CacheChoice = "auto"
+	CacheYes  CacheChoice = "yes"
+)
+
+// Cache returns the cache property.
+//
+// Default: "auto".
+func (m *Mpv) Cache() (CacheChoice, error) {
+	v, err := Get[string](m, "cache")
+
+	return CacheChoice(v), err
+}
+
+// SetCache sets the cache property.
+func (m *Mpv) SetCache(v CacheChoice) error {
+	return Set(m, "cache", string(v))
+}
+
+// CacheBufferingState returns the cache-buffering-state property.
+func (m *Mpv) CacheBufferingState() (int64, error) {
+	return Get[int64](m, "cache-buffering-state")
+}
+
+// ChapterCount returns the chapters property.
+func (m *Mpv) ChapterCount() (int64, error) {
+	return Get[int64](m, "chapters")
+}
+
+// ContainerFPS returns the container-fps property.
+func (m *Mpv) ContainerFPS() (float64, error) {
+	return Get[float64](m, "container-fps")
+}
+
+// Contrast returns the contrast property.
+//
+// Default: 0.
+// Range: -100 to 100.
+func (m *Mpv) Contrast() (float64, error) {
+	return Get[float64](m, "contrast")
+}
+
+// SetContrast sets the contrast property.
+func (m *Mpv) SetContrast(v float64) error {
+	return Set(m, "contrast", v)
+}
+
+// CoreIdle returns the core-idle property.
+func (m *Mpv) CoreIdle() (bool, error) {
+	return Get[bool](m, "core-idle")
+}
+
+// CurrentChapter returns the chapter property.
+func (m *Mpv) CurrentChapter() (int64, error) {
+	return Get[int64](m, "chapter")
+}
+
+// SetCurrentChapter sets the chapter property.
+func (m *Mpv) SetCurrentChapter(v int64) error {
+	return Set(m, "chapter", v)
+}
+
+// CursorAutohideChoice is a value of the cursor-autohide option.
+type CursorAutohideChoice string
+
+// CursorAutohideChoice values.
+const (
+	CursorAutohideNo     CursorAutohideChoice = "no"
+	CursorAutohideAlways CursorAutohideChoice = "always"
+)
+
+// CursorAutohide returns the cursor-autohide property.
+//
+// Default: 1000.
+// Range: 0 to 30000.
+// Besides the choices, the value can be a number in the range.
+func (m *Mpv) CursorAutohide() (CursorAutohideChoice, error) {
+	v, err := Get[string](m, "cursor-autohide")
+
+	return CursorAutohideChoice(v), err
+}
+
+// SetCursorAutohide sets the cursor-autohide property.
+func (m *Mpv) SetCursorAutohide(v CursorAutohideChoice) error {
+	return Set(m, "cursor-autohide", string(v))
+}
+
+// DeinterlaceChoice is a value of the deinterlace option.
+type DeinterlaceChoice string
+
+// DeinterlaceChoice values.
+const (
+	DeinterlaceNo   DeinterlaceChoice = "no"
+	DeinterlaceYes  DeinterlaceChoice = "yes"
+	DeinterlaceAuto DeinterlaceChoice = "auto"
+)
+
+// Deinterlace returns the deinterlace property.
+//
+// Default: "no".
+func (m *Mpv) Deinterlace() (DeinterlaceChoice, error) {
+	v, err := Get[string](m, "deinterlace")
+
+	return DeinterlaceChoice(v), err
+}
+
+// SetDeinterlace sets the deinterlace property.
+func (m *Mpv) SetDeinterlace(v DeinterlaceChoice) error {
+	return Set(m, "deinterlace", string(v))
+}
+
+// DemuxerReadaheadSecs returns the demuxer-readahead-secs property.
+//
+// Default: 1.
+// Minimum: 0.
+func (m *Mpv) DemuxerReadaheadSecs() (float64, error) {
+	return Get[float64](m, "demuxer-readahead-secs")
+}
+
+// SetDemuxerReadaheadSecs sets the demuxer-readahead-secs property.
+func (m *Mpv) SetDemuxerReadaheadSecs(v float64) error {
+	return Set(m, "demuxer-readahead-secs", v)
+}
+
+// DisplayFPS returns the display-fps property.
+func (m *Mpv) DisplayFPS() (float64, error) {
+	return Get[float64](m, "display-fps")
+}
+
+// Duration returns the duration property.
+func (m *Mpv) Duration() (time.Duration, error) {
+	v, err := Get[float64](m, "duration")
+
+	return time.Duration(v * float64(time.Second)), err
+}
+
+// EofReached returns the eof-reached property.
+func (m *Mpv) EofReached() (bool, error) {
+	return Get[bool](m, "eof-reached")
+}
+
+// EstimatedFrameCount returns the estimated-frame-count property.
+func (m *Mpv) EstimatedFrameCount() (int64, error) {
+	return Get[int64](m, "estimated-frame-count")
+}
+
+// EstimatedFrameNumber returns the estimated-frame-number property.
+func (m *Mpv) EstimatedFrameNumber() (int64, error) {
+	return Get[int64](m, "estimated-frame-number")
+}
+
+// EstimatedVfFPS returns the estimated-vf-fps property.
+func (m *Mpv) EstimatedVfFPS() (float64, error) {
+	return Get[float64](m, "estimated-vf-fps")
+}
+
+// FFmpegVersion returns the ffmpeg-version property.
+func (m *Mpv) FFmpegVersion() (string, error) {
+	return Get[string](m, "ffmpeg-version")
+}
+
+// FileSize returns the file-size property.
+func (m *Mpv) FileSize() (int64, error) {
+	return Get[int64](m, "file-size")
+}
+
+// Filename returns the filename property.
+func (m *Mpv) Filename() (string, error) {
+	return Get[string](m, "filename")
+}
+
+// ForceWindowChoice is a value of the force-window option.
+type ForceWindowChoice string
+
+// ForceWindowChoice values.
+const (
+	ForceWindowNo        ForceWindowChoice = "no"
+	ForceWindowYes       ForceWindowChoice = "yes"
+	ForceWindowImmediate ForceWindowChoice = "immediate"
+)
+
+// ForceWindow returns the force-window property.
+//
+// Default: "no".
+func (m *Mpv) ForceWindow() (ForceWindowChoice, error) {
+	v, err := Get[string](m, "force-window")
+
+	return ForceWindowChoice(v), err
+}
+
+// SetForceWindow sets the force-window property.
+func (m *Mpv) SetForceWindow(v ForceWindowChoice) error {
+	return Set(m, "force-window", string(v))
+}
+
+// Fullscreen returns the fullscreen property.
+//
+// Default: no.
+func (m *Mpv) Fullscreen() (bool, error) {
+	return Get[bool](m, "fullscreen")
+}
+
+// SetFullscreen sets the fullscreen property.
+func (m *Mpv) SetFullscreen(v bool) error {
+	return Set(m, "fullscreen", v)
+}
+
+// Gamma returns the gamma property.
+//
+// Default: 0.
+// Range: -100 to 100.
+func (m *Mpv) Gamma() (float64, error) {
+	return Get[float64](m, "gamma")
+}
+
+// SetGamma sets the gamma property.
+func (m *Mpv) SetGamma(v float64) error {
+	return Set(m, "gamma", v)
+}
+
+// GaplessAudioChoice is a value of the gapless-audio option.
+type GaplessAudioChoice string
+
+// GaplessAudioChoice values.
+const (
+	GaplessAudioNo   GaplessAudioChoice = "no"
+	GaplessAudioYes  GaplessAudioChoice = "yes"
+	GaplessAudioWeak GaplessAudioChoice = "weak"
+)
+
+// GaplessAudio returns the gapless-audio property.
+//
+// Default: "weak".
+func (m *Mpv) GaplessAudio() (GaplessAudioChoice, error) {
+	v, err := Get[string](m, "gapless-audio")
+
+	return GaplessAudioChoice(v), err
+}
+
+// SetGaplessAudio sets the gapless-audio property.
+func (m *Mpv) SetGaplessAudio(v GaplessAudioChoice) error {
+	return Set(m, "gapless-audio", string(v))
+}
+
+// HRSeekChoice is a value of the hr-seek option.
+type HRSeekChoice string
+
+// HRSeekChoice values.
+const (
+	HRSeekNo       HRSeekChoice = "no"
+	HRSeekAbsolute HRSeekChoice = "absolute"
+	HRSeekYes      HRSeekChoice = "yes"
+	HRSeekAlways   HRSeekChoice = "always"
+	HRSeekDefault  HRSeekChoice = "default"
+)
+
+// HRSeek returns the hr-seek property.
+//
+// Default: "default".
+func (m *Mpv) HRSeek() (HRSeekChoice, error) {
+	v, err := Get[string](m, "hr-seek")
+
+	return HRSeekChoice(v), err
+}
+
+// SetHRSeek sets the hr-seek property.
+func (m *Mpv) SetHRSeek(v HRSeekChoice) error {
+	return Set(m, "hr-seek", string(v))
+}
+
+// HWDec returns the hwdec property.
+func (m *Mpv) HWDec() (string, error) {
+	return Get[string](m, "hwdec")
+}
+
+// SetHWDec sets the hwdec property.
+func (m *Mpv) SetHWDec(v string) error {
+	return Set(m, "hwdec", v)
+}
+
+// HWDecCurrent returns the hwdec-current property.
+func (m *Mpv) HWDecCurrent() (string, error) {
+	return Get[string](m, "hwdec-current")
+}
+
+// Height returns the height property.
+func (m *Mpv) Height() (int64, error) {
+	return Get[int64](m, "height")
+}
+
+// Hue returns the hue property.
+//
+// Default: 0.
+// Range: -100 to 100.
+func (m *Mpv) Hue() (float64, error) {
+	return Get[float64](m, "hue")
+}
+
+// SetHue sets the hue property.
+func (m *Mpv) SetHue(v float64) error {
+	return Set(m, "hue", v)
+}
+
+// IdleChoice is a value of the idle option.
+type IdleChoice string
+
+// IdleChoice values.
+const (
+	IdleNo   IdleChoice = "no"
+	IdleOnce IdleChoice = "once"
+	IdleYes  IdleChoice = "yes"
+)
+
+// Idle returns the idle property.
+//
+// Default: "no".
+func (m *Mpv) Idle() (IdleChoice, error) {
+	v, err := Get[string](m, "idle")
+
+	return IdleChoice(v), err
+}
+
+// SetIdle sets the idle property.
+func (m *Mpv) SetIdle(v IdleChoice) error {
+	return Set(m, "idle", string(v))
+}
+
+// IdleActive returns the idle-active property.
+func (m *Mpv) IdleActive() (bool, error) {
+	return Get[bool](m, "idle-active")
+}
+
+// ImageDisplayDuration returns the image-display-duration property.
+//
+// Default: 1.
+// Minimum: 0.
+func (m *Mpv) ImageDisplayDuration() (float64, error) {
+	return Get[float64](m, "image-display-duration")
+}
+
+// SetImageDisplayDuration sets the image-display-duration property.
+func (m *Mpv) SetImageDisplayDuration(v float64) error {
+	return Set(m, "image-display-duration", v)
+}
+
+// Interpolation returns the interpolation property.
+//
+// Default: no.
+func (m *Mpv) Interpolation() (bool, error) {
+	return Get[bool](m, "interpolation")
+}
+
+// SetInterpolation sets the interpolation property.
+func (m *Mpv) SetInterpolation(v bool) error {
+	return Set(m, "interpolation", v)
+}
+
+// KeepOpenChoice is a value of the keep-open option.
+type KeepOpenChoice string
+
+// KeepOpenChoice values.
+const (
+	KeepOpenNo     KeepOpenChoice = "no"
+	KeepOpenYes    KeepOpenChoice = "yes"
+	KeepOpenAlways KeepOpenChoice = "always"
+)
+
+// KeepOpen returns the keep-open property.
+//
+// Default: "no".
+func (m *Mpv) KeepOpen() (KeepOpenChoice, error) {
+	v, err := Get[string](m, "keep-open")
+
+	return KeepOpenChoice(v), err
+}
+
+// SetKeepOpen sets the keep-open property.
+func (m *Mpv) SetKeepOpen(v KeepOpenChoice) error {
+	return Set(m, "keep-open", string(v))
+}
+
+// LoopFileChoice is a value of the loop-file option.
+type LoopFileChoice string
+
+// LoopFileChoice values.
+const (
+	LoopFileNo  LoopFileChoice = "no"
+	LoopFileInf LoopFileChoice = "inf"
+	LoopFileYes LoopFileChoice = "yes"
+)
+
+// LoopFile returns the loop-file property.
+//
+// Default: "no".
+// Range: 0 to 10000.
+// Besides the choices, the value can be a number in the range.
+func (m *Mpv) LoopFile() (LoopFileChoice, error) {
+	v, err := Get[string](m, "loop-file")
+
+	return LoopFileChoice(v), err
+}
+
+// SetLoopFile sets the loop-file property.
+func (m *Mpv) SetLoopFile(v LoopFileChoice) error {
+	return Set(m, "loop-file", string(v))
+}
+
+// LoopPlaylistChoice is a value of the loop-playlist option.
+type LoopPlaylistChoice string
+
+// LoopPlaylistChoice values.
+const (
+	LoopPlaylistNo    LoopPlaylistChoice = "no"
+	LoopPlaylistInf   LoopPlaylistChoice = "inf"
+	LoopPlaylistYes   LoopPlaylistChoice = "yes"
+	LoopPlaylistForce LoopPlaylistChoice = "force"
+)
+
+// LoopPlaylist returns the loop-playlist property.
+//
+// Default: "no".
+// Range: 1 to 10000.
+// Besides the choices, the value can be a number in the range.
+func (m *Mpv) LoopPlaylist() (LoopPlaylistChoice, error) {
+	v, err := Get[string](m, "loop-playlist")
+
+	return LoopPlaylistChoice(v), err
+}
+
+// SetLoopPlaylist sets the loop-playlist property.
+func (m *Mpv) SetLoopPlaylist(v LoopPlaylistChoice) error {
+	return Set(m, "loop-playlist", string(v))
+}
+
+// MediaTitle returns the media-title property.
+func (m *Mpv) MediaTitle() (string, error) {
+	return Get[string](m, "media-title")
+}
+
+// MpvVersion returns the mpv-version property.
+func (m *Mpv) MpvVersion() (string, error) {
+	return Get[string](m, "mpv-version")
+}
+
+// Mute returns the mute property.
+//
+// Default: no.
+func (m *Mpv) Mute() (bool, error) {
+	return Get[bool](m, "mute")
+}
+
+// SetMute sets the mute property.
+func (m *Mpv) SetMute(v bool) error {
+	return Set(m, "mute", v)
+}
+
+// OSDLevelChoice is a value of the osd-level option.
+type OSDLevelChoice string
+
+// OSDLevelChoice values.
+const (
+	OSDLevel0 OSDLevelChoice = "0"
+	OSDLevel1 OSDLevelChoice = "1"
+	OSDLevel2 OSDLevelChoice = "2"
+	OSDLevel3 OSDLevelChoice = "3"
+)
+
+// OSDLevel returns the osd-level property.
+//
+// Default: "1".
+func (m *Mpv) OSDLevel() (OSDLevelChoice, error) {
+	v, err := Get[string](m, "osd-level")
+
+	return OSDLevelChoice(v), err
+}
+
+// SetOSDLevel sets the osd-level property.
+func (m *Mpv) SetOSDLevel(v OSDLevelChoice) error {
+	return Set(m, "osd-level", string(v))
+}
+
+// Ontop returns the ontop property.
+//
+// Default: no.
+func (m *Mpv) Ontop() (bool, error) {
+	return Get[bool](m, "ontop")
+}
+
+// SetOntop sets the ontop property.
+func (m *Mpv) SetOntop(v bool) error {
+	return Set(m, "ontop", v)
+}
+
+// Panscan returns the panscan property.
+//
+// Default: 0.
+// Range: 0 to 1.
+func (m *Mpv) Panscan() (float64, error) {
+	return Get[float64](m, "panscan")
+}
+
+// SetPanscan sets the panscan property.
+func (m *Mpv) SetPanscan(v float64) error {
+	return Set(m, "panscan", v)
+}
+
+// Path returns the path property.
+func (m *Mpv) Path() (string, error) {
+	return Get[string](m, "path")
+}
+
+// Pause returns the pause property.
+//
+// Default: no.
+func (m *Mpv) Pause() (bool, error) {
+	return Get[bool](m, "pause")
+}
+
+// SetPause sets the pause property.
+func (m *Mpv) SetPause(v bool) error {
+	return Set(m, "pause", v)
+}
+
+// PausedForCache returns the paused-for-cache property.
+func (m *Mpv) PausedForCache() (bool, error) {
+	return Get[bool](m, "paused-for-cache")
+}
+
+// PercentPos returns the percent-pos property.
+func (m *Mpv) PercentPos() (float64, error) {
+	return Get[float64](m, "percent-pos")
+}
+
+// SetPercentPos sets the percent-pos property.
+func (m *Mpv) SetPercentPos(v float64) error {
+	return Set(m, "percent-pos", v)
+}
+
+// PlaybackTime returns the playback-time property.
+func (m *Mpv) PlaybackTime() (time.Duration, error) {
+	v, err := Get[float64](m, "playback-time")
+
+	return time.Duration(v * float64(time.Second)), err
+}
+
+// SetPlaybackTime sets the playback-time property.
+func (m *Mpv) SetPlaybackTime(v time.Duration) error {
+	return Set(m, "playback-time", v.Seconds())
+}
+
+// PlaylistCount returns the playlist-count property.
+func (m *Mpv) PlaylistCount() (int64, error) {
+	return Get[int64](m, "playlist-count")
+}
+
+// PlaylistPos returns the playlist-pos property.
+func (m *Mpv) PlaylistPos() (int64, error) {
+	return Get[int64](m, "playlist-pos")
+}
+
+// SetPlaylistPos sets the playlist-pos property.
+func (m *Mpv) SetPlaylistPos(v int64) error {
+	return Set(m, "playlist-pos", v)
+}
+
+// ReplaygainChoice is a value of the replaygain option.
+type ReplaygainChoice string
+
+// ReplaygainChoice values.
+const (
+	ReplaygainNo    ReplaygainChoice = "no"
+	ReplaygainTrack ReplaygainChoice = "track"
+	ReplaygainAlbum ReplaygainChoice = "album"
+)
+
+// Replaygain returns the replaygain property.
+//
+// Default: "no".
+func (m *Mpv) Replaygain() (ReplaygainChoice, error) {
+	v, err := Get[string](m, "replaygain")
+
+	return ReplaygainChoice(v), err
+}
+
+// SetReplaygain sets the replaygain property.
+func (m *Mpv) SetReplaygain(v ReplaygainChoice) error {
+	return Set(m, "replaygain", string(v))
+}
+
+// SID returns the sid property.
+//
+// Default: "auto".
+// Range: 0 to 8190.
+func (m *Mpv) SID() (TrackID, error) {
+	v, err := Get[string](m, "sid")
+	if err != nil {
+		return 0, err
+	}
+
+	return parseTrackID(v)
+}
+
+// SetSID sets the sid property.
+func (m *Mpv) SetSID(v TrackID) error {
+	return Set(m, "sid", v.String())
+}
+
+// Saturation returns the saturation property.
+//
+// Default: 0.
+// Range: -100 to 100.
+func (m *Mpv) Saturation() (float64, error) {
+	return Get[float64](m, "saturation")
+}
+
+// SetSaturation sets the saturation property.
+func (m *Mpv) SetSaturation(v float64) error {
+	return Set(m, "saturation", v)
+}
+
+// ScreenshotDir returns the screenshot-dir property.
+func (m *Mpv) ScreenshotDir() (string, error) {
+	return Get[string](m, "screenshot-dir")
+}
+
+// SetScreenshotDir sets the screenshot-dir property.
+func (m *Mpv) SetScreenshotDir(v string) error {
+	return Set(m, "screenshot-dir", v)
+}
+
+// ScreenshotFormatChoice is a value of the screenshot-format option.
+type ScreenshotFormatChoice string
+
+// ScreenshotFormatChoice values.
+const (
+	ScreenshotFormatJpg  ScreenshotFormatChoice = "jpg"
+	ScreenshotFormatJpeg ScreenshotFormatChoice = "jpeg"
+	ScreenshotFormatPng  ScreenshotFormatChoice = "png"
+	ScreenshotFormatWebp ScreenshotFormatChoice = "webp"
+	ScreenshotFormatJxl  ScreenshotFormatChoice = "jxl"
+	ScreenshotFormatAvif ScreenshotFormatChoice = "avif"
+)
+
+// ScreenshotFormat returns the screenshot-format property.
+//
+// Default: "jpg".
+func (m *Mpv) ScreenshotFormat() (ScreenshotFormatChoice, error) {
+	v, err := Get[string](m, "screenshot-format")
+
+	return ScreenshotFormatChoice(v), err
+}
+
+// SetScreenshotFormat sets the screenshot-format property.
+func (m *Mpv) SetScreenshotFormat(v ScreenshotFormatChoice) error {
+	return Set(m, "screenshot-format", string(v))
+}
+
+// Seeking returns the seeking property.
+func (m *Mpv) Seeking() (bool, error) {
+	return Get[bool](m, "seeking")
+}
+
+// Speed returns the speed property.
+//
+// Default: 1.
+// Range: 0.01 to 100.
+func (m *Mpv) Speed() (float64, error) {
+	return Get[float64](m, "speed")
+}
+
+// SetSpeed sets the speed property.
+func (m *Mpv) SetSpeed(v float64) error {
+	return Set(m, "speed", v)
+}
+
+// SubAutoChoice is a value of the sub-auto option.
+type SubAutoChoice string
+
+// SubAutoChoice values.
+const (
+	SubAutoNo    SubAutoChoice = "no"
+	SubAutoExact SubAutoChoice = "exact"
+	SubAutoFuzzy SubAutoChoice = "fuzzy"
+	SubAutoAll   SubAutoChoice = "all"
+)
+
+// SubAuto returns the sub-auto property.
+//
+// Default: "exact".
+func (m *Mpv) SubAuto() (SubAutoChoice, error) {
+	v, err := Get[string](m, "sub-auto")
+
+	return SubAutoChoice(v), err
+}
+
+// SetSubAuto sets the sub-auto property.
+func (m *Mpv) SetSubAuto(v SubAutoChoice) error {
+	return Set(m, "sub-auto", string(v))
+}
+
+// SubDelay returns the sub-delay property.
+//
+// Default: 0.
+func (m *Mpv) SubDelay() (float64, error) {
+	return Get[float64](m, "sub-delay")
+}
+
+// SetSubDelay sets the sub-delay property.
+func (m *Mpv) SetSubDelay(v float64) error {
+	return Set(m, "sub-delay", v)
+}
+
+// SubPos returns the sub-pos property.
+//
+// Default: 100.
+// Range: 0 to 150.
+func (m *Mpv) SubPos() (float64, error) {
+	return Get[float64](m, "sub-pos")
+}
+
+// SetSubPos sets the sub-pos property.
+func (m *Mpv) SetSubPos(v float64) error {
+	return Set(m, "sub-pos", v)
+}
+
+// SubScale returns the sub-scale property.
+//
+// Default: 1.
+// Range: 0 to 100.
+func (m *Mpv) SubScale() (float64, error) {
+	return Get[float64](m, "sub-scale")
+}
+
+// SetSubScale sets the sub-scale property.
+func (m *Mpv) SetSubScale(v float64) error {
+	return Set(m, "sub-scale", v)
+}
+
+// SubVisibility returns the sub-visibility property.
+//
+// Default: yes.
+func (m *Mpv) SubVisibility() (bool, error) {
+	return Get[bool](m, "sub-visibility")
+}
+
+// SetSubVisibility sets the sub-visibility property.
+func (m *Mpv) SetSubVisibility(v bool) error {
+	return Set(m, "sub-visibility", v)
+}
+
+// TimePos returns the time-pos property.
+func (m *Mpv) TimePos() (time.Duration, error) {
+	v, err := Get[float64](m, "time-pos")
+
+	return time.Duration(v * float64(time.Second)), err
+}
+
+// SetTimePos sets the time-pos property.
+func (m *Mpv) SetTimePos(v time.Duration) error {
+	return Set(m, "time-pos", v.Seconds())
+}
+
+// TimeRemaining returns the time-remaining property.
+func (m *Mpv) TimeRemaining() (time.Duration, error) {
+	v, err := Get[float64](m, "time-remaining")
+
+	return time.Duration(v * float64(time.Second)), err
+}
+
+// Title returns the title property.
+//
+// Default: "${?media-title:${media-title}}${!media-title:No file} - mpv".
+func (m *Mpv) Title() (string, error) {
+	return Get[string](m, "title")
+}
+
+// SetTitle sets the title property.
+func (m *Mpv) SetTitle(v string) error {
+	return Set(m, "title", v)
+}
+
+// VID returns the vid property.
+//
+// Default: "auto".
+// Range: 0 to 8190.
+func (m *Mpv) VID() (TrackID, error) {
+	v, err := Get[string](m, "vid")
+	if err != nil {
+		return 0, err
+	}
+
+	return parseTrackID(v)
+}
+
+// SetVID sets the vid property.
+func (m *Mpv) SetVID(v TrackID) error {
+	return Set(m, "vid", v.String())
+}
+
+// VO returns the vo property.
+func (m *Mpv) VO() (string, error) {
+	return Get[string](m, "vo")
+}
+
+// SetVO sets the vo property.
+func (m *Mpv) SetVO(v string) error {
+	return Set(m, "vo", v)
+}
+
+// VideoRotateChoice is a value of the video-rotate option.
+type VideoRotateChoice string
+
+// VideoRotateChoice values.
+const (
+	VideoRotateNo VideoRotateChoice = "no"
+)
+
+// VideoRotate returns the video-rotate property.
+//
+// Default: 0.
+// Range: 0 to 359.
+// Besides the choices, the value can be a number in the range.
+func (m *Mpv) VideoRotate() (VideoRotateChoice, error) {
+	v, err := Get[string](m, "video-rotate")
+
+	return VideoRotateChoice(v), err
+}
+
+// SetVideoRotate sets the video-rotate property.
+func (m *Mpv) SetVideoRotate(v VideoRotateChoice) error {
+	return Set(m, "video-rotate", string(v))
+}
+
+// VideoSyncChoice is a value of the video-sync option.
+type VideoSyncChoice string
+
+// VideoSyncChoice values.
+const (
+	VideoSyncAudio                 VideoSyncChoice = "audio"
+	VideoSyncDisplayResample       VideoSyncChoice = "display-resample"
+	VideoSyncDisplayResampleVdrop  VideoSyncChoice = "display-resample-vdrop"
+	VideoSyncDisplayResampleDesync VideoSyncChoice = "display-resample-desync"
+	VideoSyncDisplayTempo          VideoSyncChoice = "display-tempo"
+	VideoSyncDisplayAdrop          VideoSyncChoice = "display-adrop"
+	VideoSyncDisplayVdrop          VideoSyncChoice = "display-vdrop"
+	VideoSyncDisplayDesync         VideoSyncChoice = "display-desync"
+	VideoSyncDesync                VideoSyncChoice = "desync"
+)
+
+// VideoSync returns the video-sync property.
+//
+// Default: "audio".
+func (m *Mpv) VideoSync() (VideoSyncChoice, error) {
+	v, err := Get[string](m, "video-sync")
+
+	return VideoSyncChoice(v), err
+}
+
+// SetVideoSync sets the video-sync property.
+func (m *Mpv) SetVideoSync(v VideoSyncChoice) error {
+	return Set(m, "video-sync", string(v))
+}
+
+// VideoZoom returns the video-zoom property.
+//
+// Default: 0.
+// Range: -20 to 20.
+func (m *Mpv) VideoZoom() (float64, error) {
+	return Get[float64](m, "video-zoom")
+}
+
+// SetVideoZoom sets the video-zoom property.
+func (m *Mpv) SetVideoZoom(v float64) error {
+	return Set(m, "video-zoom", v)
+}
+
+// Volume returns the volume property.
+//
+// Default: 100.
+// Range: -1 to 1000.
+func (m *Mpv) Volume() (float64, error) {
+	return Get[float64](m, "volume")
+}
+
+// SetVolume sets the volume property.
+func (m *Mpv) SetVolume(v float64) error {
+	return Set(m, "volume", v)
+}
+
+// VolumeMax returns the volume-max property.
+//
+// Default: 130.
+// Range: 100 to 1000.
+func (m *Mpv) VolumeMax() (float64, error) {
+	return Get[float64](m, "volume-max")
+}
+
+// SetVolumeMax sets the volume-max property.
+func (m *Mpv) SetVolumeMax(v float64) error {
+	return Set(m, "volume-max", v)
+}
+
+// Width returns the width property.
+func (m *Mpv) Width() (int64, error) {
+	return Get[int64](m, "width")
+}
+
+// YTDL returns the ytdl property.
+//
+// Default: yes.
+func (m *Mpv) YTDL() (bool, error) {
+	return Get[bool](m, "ytdl")
+}
+
+// SetYTDL sets the ytdl property.
+func (m *Mpv) SetYTDL(v bool) error {
+	return Set(m, "ytdl", v)
+}
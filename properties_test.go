@@ -0,0 +1,132 @@
+package mpv
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTypedProperties(t *testing.T) {
+	m := newHeadless(t)
+
+	if err := m.Command([]string{"loadfile", "testdata/test.mpg"}); err != nil {
+		t.Fatalf("loadfile: %v", err)
+	}
+
+	var loaded, reconfig bool
+	for !loaded || !reconfig {
+		e := m.WaitEvent(10)
+		switch e.EventID {
+		case EventFileLoaded:
+			loaded = true
+		case EventVideoReconfig:
+			reconfig = loaded
+		case EventEnd:
+			ef, _ := e.EndFile()
+			t.Fatalf("playback ended early: %v", ef.Reason)
+		case EventNone, EventShutdown:
+			t.Fatal("file did not load")
+		}
+	}
+
+	tracks, err := m.Tracks()
+	if err != nil {
+		t.Fatalf("Tracks: %v", err)
+	}
+	var video *Track
+	for i := range tracks {
+		if tracks[i].Type == "video" {
+			video = &tracks[i]
+		}
+	}
+	if video == nil {
+		t.Fatalf("no video track in %+v", tracks)
+	}
+	if video.ID == 0 || video.Codec == "" || !video.Selected || video.DemuxW == 0 {
+		t.Errorf("video track = %+v", *video)
+	}
+
+	playlist, err := m.Playlist()
+	if err != nil {
+		t.Fatalf("Playlist: %v", err)
+	}
+	if len(playlist) != 1 || playlist[0].Filename != "testdata/test.mpg" || !playlist[0].Current {
+		t.Errorf("Playlist = %+v", playlist)
+	}
+
+	if _, err := m.Chapters(); err != nil {
+		t.Errorf("Chapters: %v", err)
+	}
+	if _, err := m.Metadata(); err != nil {
+		t.Errorf("Metadata: %v", err)
+	}
+
+	vp, err := m.VideoParams()
+	if err != nil {
+		t.Fatalf("VideoParams: %v", err)
+	}
+	if vp.W != video.DemuxW || vp.H != video.DemuxH || vp.PixelFormat == "" {
+		t.Errorf("VideoParams = %+v, track is %dx%d", vp, video.DemuxW, video.DemuxH)
+	}
+
+	cs, err := m.DemuxerCacheState()
+	if err != nil {
+		t.Fatalf("DemuxerCacheState: %v", err)
+	}
+	if cs.CacheEnd < 0 {
+		t.Errorf("DemuxerCacheState = %+v", cs)
+	}
+}
+
+func TestGeneratedAccessors(t *testing.T) {
+	m := newHeadless(t)
+
+	if err := m.SetVolume(42); err != nil {
+		t.Fatalf("SetVolume: %v", err)
+	}
+	if v, err := m.Volume(); err != nil || v != 42 {
+		t.Errorf("Volume = %v, %v, want 42", v, err)
+	}
+
+	if err := m.SetKeepOpen(KeepOpenAlways); err != nil {
+		t.Fatalf("SetKeepOpen: %v", err)
+	}
+	if v, err := m.KeepOpen(); err != nil || v != KeepOpenAlways {
+		t.Errorf("KeepOpen = %q, %v, want always", v, err)
+	}
+
+	if v, err := m.IdleActive(); err != nil || !v {
+		t.Errorf("IdleActive = %v, %v, want true", v, err)
+	}
+	if _, err := m.TimePos(); !errors.Is(err, ErrPropertyUnavailable) {
+		t.Errorf("TimePos without a file: err = %v, want ErrPropertyUnavailable", err)
+	}
+
+	if err := m.SetSID(TrackIDNo); err != nil {
+		t.Fatalf("SetSID: %v", err)
+	}
+	if v, err := m.SID(); err != nil || v != TrackIDNo {
+		t.Errorf("SID = %v, %v, want no", v, err)
+	}
+}
+
+func TestTrackID(t *testing.T) {
+	for _, tt := range []struct {
+		s  string
+		id TrackID
+	}{
+		{"no", TrackIDNo},
+		{"auto", TrackIDAuto},
+		{"2", 2},
+	} {
+		if id, err := parseTrackID(tt.s); err != nil || id != tt.id {
+			t.Errorf("parseTrackID(%q) = %v, %v, want %v", tt.s, id, err, tt.id)
+		}
+		if s := tt.id.String(); s != tt.s {
+			t.Errorf("TrackID(%d).String() = %q, want %q", int64(tt.id), s, tt.s)
+		}
+	}
+
+	if _, err := parseTrackID("first"); !errors.Is(err, ErrPropertyFormat) {
+		t.Errorf("parseTrackID(first): err = %v, want ErrPropertyFormat", err)
+	}
+}
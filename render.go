@@ -15,6 +15,117 @@ const (
 // video frame should be rendered.
 const RenderUpdateFrame uint64 = 1 << 0
 
+// mpv_render_param_type values.
+const (
+	renderParamAPIType            = 1
+	renderParamOpenGLInitParams   = 2
+	renderParamOpenGLFBO          = 3
+	renderParamFlipY              = 4
+	renderParamDepth              = 5
+	renderParamICCProfile         = 6
+	renderParamAmbientLight       = 7
+	renderParamAdvancedControl    = 10
+	renderParamNextFrameInfo      = 11
+	renderParamBlockForTargetTime = 12
+	renderParamSkipRendering      = 13
+	renderParamSWSize             = 17
+	renderParamSWFormat           = 18
+	renderParamSWStride           = 19
+	renderParamSWPointer          = 20
+)
+
+// RenderCreateParams are the optional parameters for creating a render context, the same in
+// both backends. A nil *RenderCreateParams uses mpv's defaults.
+type RenderCreateParams struct {
+	// AdvancedControl enables advanced render control. The update callback must then always
+	// be set and Update must be called after every callback, in exchange mpv can do more work
+	// on the render thread and GetInfo is useful.
+	AdvancedControl bool
+}
+
+// ints returns the parameters as type and value pairs.
+func (p *RenderCreateParams) ints() []int32 {
+	if p == nil || !p.AdvancedControl {
+		return nil
+	}
+
+	return []int32{renderParamAdvancedControl, 1}
+}
+
+// RenderParams are the optional parameters for rendering a frame, the same in both backends.
+// A nil *RenderParams uses mpv's defaults.
+type RenderParams struct {
+	// BlockForTargetTime sets whether rendering waits until the target time of the frame,
+	// which mpv does by default. Disable it to schedule frames with GetInfo.
+	BlockForTargetTime *bool
+	// SkipRendering updates the internal frame state without drawing anything.
+	SkipRendering bool
+	// Depth is the bit depth of the render target per color component, used for dithering
+	// by the OpenGL renderer. 0 uses mpv's default.
+	Depth int
+}
+
+// ints returns the parameters as type and value pairs.
+func (p *RenderParams) ints() []int32 {
+	if p == nil {
+		return nil
+	}
+
+	var ints []int32
+	if p.BlockForTargetTime != nil {
+		ints = append(ints, renderParamBlockForTargetTime, boolInt(*p.BlockForTargetTime))
+	}
+	if p.SkipRendering {
+		ints = append(ints, renderParamSkipRendering, 1)
+	}
+	if p.Depth > 0 {
+		ints = append(ints, renderParamDepth, int32(p.Depth))
+	}
+
+	return ints
+}
+
+func boolInt(b bool) int32 {
+	if b {
+		return 1
+	}
+
+	return 0
+}
+
+// RenderFrameInfo describes the next frame, see RenderContext.GetInfo.
+type RenderFrameInfo struct {
+	// Present is set if there is a frame at all. The other fields are only valid if it is.
+	Present bool
+	// Redraw is set if the frame is a redraw of the previous one, e.g. after a resize.
+	Redraw bool
+	// Repeat is set if the frame is repeated for display sync, no new video frame.
+	Repeat bool
+	// BlockVsync is set if the caller should wait for vsync before rendering the frame.
+	BlockVsync bool
+	// TargetTime is when the frame should be displayed, in the TimeUS clock, or 0 if it
+	// should be displayed as soon as possible.
+	TargetTime int64
+}
+
+// mpv_render_frame_info_flag values.
+const (
+	renderFrameInfoPresent    = 1 << 0
+	renderFrameInfoRedraw     = 1 << 1
+	renderFrameInfoRepeat     = 1 << 2
+	renderFrameInfoBlockVsync = 1 << 3
+)
+
+func newRenderFrameInfo(flags uint64, targetTime int64) RenderFrameInfo {
+	return RenderFrameInfo{
+		Present:    flags&renderFrameInfoPresent != 0,
+		Redraw:     flags&renderFrameInfoRedraw != 0,
+		Repeat:     flags&renderFrameInfoRepeat != 0,
+		BlockVsync: flags&renderFrameInfoBlockVsync != 0,
+		TargetTime: targetTime,
+	}
+}
+
 // Render callbacks live in a token-keyed registry; the token is passed to C as the
 // callback context, so one trampoline dispatches without handing Go pointers to C.
 var (
@@ -12,49 +12,75 @@ package mpv
 void *goMpvGetProcAddress(void *ctx, const char *name);
 void goMpvRenderUpdate(void *ctx);
 
-static int render_create_sw(mpv_render_context **ctx, mpv_handle *mpv) {
-    mpv_render_param params[] = {
+#define MAX_INT_PARAMS 4
+
+// add_int_params appends n int parameters, given as type and value pairs, and the terminator.
+static void add_int_params(mpv_render_param *params, int *ints, int n) {
+    for (int i = 0; i < n && i < MAX_INT_PARAMS; i++) {
+        params[i].type = ints[2*i];
+        params[i].data = &ints[2*i+1];
+    }
+}
+
+static int render_create_sw(mpv_render_context **ctx, mpv_handle *mpv, int *ints, int n) {
+    mpv_render_param params[1 + MAX_INT_PARAMS + 1] = {
         {MPV_RENDER_PARAM_API_TYPE, (void *)MPV_RENDER_API_TYPE_SW},
-        {0},
     };
+    add_int_params(&params[1], ints, n);
     return mpv_render_context_create(ctx, mpv, params);
 }
 
-static int render_sw(mpv_render_context *ctx, int w, int h, const char *format, size_t stride, void *ptr) {
+static int render_sw(mpv_render_context *ctx, int w, int h, const char *format, size_t stride, void *ptr, int *ints, int n) {
     int size[2] = {w, h};
-    mpv_render_param params[] = {
+    mpv_render_param params[4 + MAX_INT_PARAMS + 1] = {
         {MPV_RENDER_PARAM_SW_SIZE, &size[0]},
         {MPV_RENDER_PARAM_SW_FORMAT, (void *)format},
         {MPV_RENDER_PARAM_SW_STRIDE, &stride},
         {MPV_RENDER_PARAM_SW_POINTER, ptr},
-        {0},
     };
+    add_int_params(&params[4], ints, n);
     return mpv_render_context_render(ctx, params);
 }
 
-static int render_create_gl(mpv_render_context **ctx, mpv_handle *mpv, uintptr_t cb_ctx) {
+static int render_create_gl(mpv_render_context **ctx, mpv_handle *mpv, uintptr_t cb_ctx, int *ints, int n) {
     mpv_opengl_init_params gl = {
         .get_proc_address = goMpvGetProcAddress,
         .get_proc_address_ctx = (void *)cb_ctx,
     };
-    mpv_render_param params[] = {
+    mpv_render_param params[2 + MAX_INT_PARAMS + 1] = {
         {MPV_RENDER_PARAM_API_TYPE, (void *)MPV_RENDER_API_TYPE_OPENGL},
         {MPV_RENDER_PARAM_OPENGL_INIT_PARAMS, &gl},
-        {0},
     };
+    add_int_params(&params[2], ints, n);
     return mpv_render_context_create(ctx, mpv, params);
 }
 
-static int render_gl(mpv_render_context *ctx, int fbo, int w, int h, int flip_y) {
+static int render_gl(mpv_render_context *ctx, int fbo, int w, int h, int flip_y, int *ints, int n) {
     mpv_opengl_fbo gl_fbo = {.fbo = fbo, .w = w, .h = h};
-    mpv_render_param params[] = {
+    mpv_render_param params[2 + MAX_INT_PARAMS + 1] = {
         {MPV_RENDER_PARAM_OPENGL_FBO, &gl_fbo},
         {MPV_RENDER_PARAM_FLIP_Y, &flip_y},
-        {0},
     };
+    add_int_params(&params[2], ints, n);
     return mpv_render_context_render(ctx, params);
 }
 
+static int render_next_frame_info(mpv_render_context *ctx, mpv_render_frame_info *info) {
+    mpv_render_param param = {MPV_RENDER_PARAM_NEXT_FRAME_INFO, info};
+    return mpv_render_context_get_info(ctx, param);
+}
+
+static int render_set_icc_profile(mpv_render_context *ctx, void *data, size_t size) {
+    mpv_byte_array icc = {data, size};
+    mpv_render_param param = {MPV_RENDER_PARAM_ICC_PROFILE, &icc};
+    return mpv_render_context_set_parameter(ctx, param);
+}
+
+static int render_set_ambient_light(mpv_render_context *ctx, int lux) {
+    mpv_render_param param = {MPV_RENDER_PARAM_AMBIENT_LIGHT, &lux};
+    return mpv_render_context_set_parameter(ctx, param);
+}
+
 static void render_set_update_callback(mpv_render_context *ctx, uintptr_t cb_ctx) {
     mpv_render_context_set_update_callback(ctx, goMpvRenderUpdate, (void *)cb_ctx);
 }
@@ -62,6 +88,7 @@ static void render_set_update_callback(mpv_render_context *ctx, uintptr_t cb_ctx
 import "C"
 
 import (
+	"fmt"
 	"unsafe"
 )
 
@@ -74,10 +101,19 @@ type RenderContext struct {
 // NewRenderContextSW creates a software (CPU) render context. The mpv instance
 // must have the "vo" option set to "libmpv".
 func (m *Mpv) NewRenderContextSW() (*RenderContext, error) {
+	return m.NewRenderContextSWWithParams(nil)
+}
+
+// NewRenderContextSWWithParams is NewRenderContextSW with creation parameters, see RenderCreateParams.
+func (m *Mpv) NewRenderContextSWWithParams(p *RenderCreateParams) (*RenderContext, error) {
+	ints, n, err := cInts(p.ints())
+	if err != nil {
+		return nil, err
+	}
 	id := registerRenderCallbacks()
 
 	var ctx *C.mpv_render_context
-	err := newError(int(C.render_create_sw(&ctx, m.handle)))
+	err = opError(int(C.render_create_sw(&ctx, m.handle, ints, n)), "render_context_create", "", nil)
 	if err != nil {
 		unregisterRenderCallbacks(id)
 		return nil, err
@@ -89,11 +125,20 @@ func (m *Mpv) NewRenderContextSW() (*RenderContext, error) {
 // NewRenderContextGL creates an OpenGL render context; getProcAddress resolves GL
 // functions. Requires vo=libmpv and the GL context current on the calling thread.
 func (m *Mpv) NewRenderContextGL(getProcAddress func(name string) unsafe.Pointer) (*RenderContext, error) {
+	return m.NewRenderContextGLWithParams(getProcAddress, nil)
+}
+
+// NewRenderContextGLWithParams is NewRenderContextGL with creation parameters, see RenderCreateParams.
+func (m *Mpv) NewRenderContextGLWithParams(getProcAddress func(name string) unsafe.Pointer, p *RenderCreateParams) (*RenderContext, error) {
+	ints, n, err := cInts(p.ints())
+	if err != nil {
+		return nil, err
+	}
 	id := registerRenderCallbacks()
 	setRenderProcAddress(id, getProcAddress)
 
 	var ctx *C.mpv_render_context
-	err := newError(int(C.render_create_gl(&ctx, m.handle, C.uintptr_t(id))))
+	err = opError(int(C.render_create_gl(&ctx, m.handle, C.uintptr_t(id), ints, n)), "render_context_create", "", nil)
 	if err != nil {
 		unregisterRenderCallbacks(id)
 		return nil, err
@@ -105,21 +150,77 @@ func (m *Mpv) NewRenderContextGL(getProcAddress func(name string) unsafe.Pointer
 // RenderSW renders the current frame into buf, which must hold stride*height
 // bytes. format is one of "rgb0", "bgr0", "0bgr", "0rgb".
 func (rc *RenderContext) RenderSW(width, height, stride int, format string, buf []byte) error {
+	return rc.RenderSWWithParams(width, height, stride, format, buf, nil)
+}
+
+// RenderSWWithParams is RenderSW with render parameters, see RenderParams.
+func (rc *RenderContext) RenderSWWithParams(width, height, stride int, format string, buf []byte, p *RenderParams) error {
+	ints, n, err := cInts(p.ints())
+	if err != nil {
+		return err
+	}
 	cformat := C.CString(format)
 	defer C.free(unsafe.Pointer(cformat))
 
-	return newError(int(C.render_sw(rc.ctx, C.int(width), C.int(height), cformat, C.size_t(stride), unsafe.Pointer(&buf[0]))))
+	return opError(int(C.render_sw(rc.ctx, C.int(width), C.int(height), cformat, C.size_t(stride), unsafe.Pointer(&buf[0]), ints, n)), "render_context_render", "", nil)
 }
 
 // RenderGL renders the current frame into the given OpenGL framebuffer object
 // (0 for the default framebuffer). Set flipY for bottom-up coordinate systems.
 func (rc *RenderContext) RenderGL(fbo, width, height int, flipY bool) error {
-	var flip C.int
-	if flipY {
-		flip = 1
+	return rc.RenderGLWithParams(fbo, width, height, flipY, nil)
+}
+
+// RenderGLWithParams is RenderGL with render parameters, see RenderParams.
+func (rc *RenderContext) RenderGLWithParams(fbo, width, height int, flipY bool, p *RenderParams) error {
+	ints, n, err := cInts(p.ints())
+	if err != nil {
+		return err
+	}
+
+	return opError(int(C.render_gl(rc.ctx, C.int(fbo), C.int(width), C.int(height), C.int(boolInt(flipY)), ints, n)), "render_context_render", "", nil)
+}
+
+// GetInfo returns information about the next frame (MPV_RENDER_PARAM_NEXT_FRAME_INFO),
+// mostly useful with RenderCreateParams.AdvancedControl.
+func (rc *RenderContext) GetInfo() (RenderFrameInfo, error) {
+	var info C.mpv_render_frame_info
+	if err := opError(int(C.render_next_frame_info(rc.ctx, &info)), "render_context_get_info", "", nil); err != nil {
+		return RenderFrameInfo{}, err
+	}
+
+	return newRenderFrameInfo(uint64(info.flags), int64(info.target_time)), nil
+}
+
+// SetICCProfile sets the ICC profile of the display, the contents of an ICC file. An empty
+// profile resets it. mpv copies the data. It only has an effect if the icc-profile-auto
+// option is enabled, and the SW renderer does not support it.
+func (rc *RenderContext) SetICCProfile(profile []byte) error {
+	var data unsafe.Pointer
+	if len(profile) > 0 {
+		data = unsafe.Pointer(&profile[0])
+	}
+
+	return opError(int(C.render_set_icc_profile(rc.ctx, data, C.size_t(len(profile)))), "render_context_set_parameter", "icc-profile", nil)
+}
+
+// SetAmbientLight sets the ambient light in lux, used by the gamma-auto option. The SW
+// renderer does not support it.
+func (rc *RenderContext) SetAmbientLight(lux int) error {
+	return opError(int(C.render_set_ambient_light(rc.ctx, C.int(lux))), "render_context_set_parameter", "ambient-light", nil)
+}
+
+// cInts returns int parameter pairs for the C helpers, which have room for MAX_INT_PARAMS.
+func cInts(ints []int32) (*C.int, C.int, error) {
+	n := len(ints) / 2
+	if n > C.MAX_INT_PARAMS {
+		return nil, 0, fmt.Errorf("%w: %d int render parameters, at most %d are supported", ErrInvalidParameter, n, C.MAX_INT_PARAMS)
+	}
+	if n == 0 {
+		return nil, 0, nil
 	}
 
-	return newError(int(C.render_gl(rc.ctx, C.int(fbo), C.int(width), C.int(height), flip)))
+	return (*C.int)(unsafe.Pointer(&ints[0])), C.int(n), nil
 }
 
 // SetUpdateCallback sets fn to run when a new frame is ready. fn runs on an mpv
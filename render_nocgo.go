@@ -9,18 +9,6 @@ import (
 	"github.com/ebitengine/purego"
 )
 
-// mpv_render_param_type values.
-const (
-	renderParamAPIType          = 1
-	renderParamOpenGLInitParams = 2
-	renderParamOpenGLFBO        = 3
-	renderParamFlipY            = 4
-	renderParamSWSize           = 17
-	renderParamSWFormat         = 18
-	renderParamSWStride         = 19
-	renderParamSWPointer        = 20
-)
-
 // cRenderParam mirrors C mpv_render_param.
 type cRenderParam struct {
 	typ  int32
@@ -38,6 +26,12 @@ type cOpenGLFBO struct {
 	fbo, w, h, internalFormat int32
 }
 
+// cRenderFrameInfo mirrors C mpv_render_frame_info.
+type cRenderFrameInfo struct {
+	flags      uint64
+	targetTime int64
+}
+
 var renderContextCreate func(ctx unsafe.Pointer, mpv uintptr, params unsafe.Pointer) int
 var renderContextRender func(ctx uintptr, params unsafe.Pointer) int
 var renderContextUpdate func(ctx uintptr) uint64
@@ -82,16 +76,20 @@ type RenderContext struct {
 // NewRenderContextSW creates a software (CPU) render context. The mpv instance
 // must have the "vo" option set to "libmpv".
 func (m *Mpv) NewRenderContextSW() (*RenderContext, error) {
+	return m.NewRenderContextSWWithParams(nil)
+}
+
+// NewRenderContextSWWithParams is NewRenderContextSW with creation parameters, see RenderCreateParams.
+func (m *Mpv) NewRenderContextSWWithParams(p *RenderCreateParams) (*RenderContext, error) {
 	id := registerRenderCallbacks()
 
 	apiType := cStr(RenderAPITypeSW)
-	params := []cRenderParam{
+	params := appendIntParams([]cRenderParam{
 		{typ: renderParamAPIType, data: unsafe.Pointer(apiType)},
-		{},
-	}
+	}, p.ints())
 
 	var ctx uintptr
-	err := newError(renderContextCreate(unsafe.Pointer(&ctx), m.handle, unsafe.Pointer(&params[0])))
+	err := opError(renderContextCreate(unsafe.Pointer(&ctx), m.handle, unsafe.Pointer(&params[0])), "render_context_create", "", nil)
 	if err != nil {
 		unregisterRenderCallbacks(id)
 		return nil, err
@@ -103,20 +101,24 @@ func (m *Mpv) NewRenderContextSW() (*RenderContext, error) {
 // NewRenderContextGL creates an OpenGL render context; getProcAddress resolves GL
 // functions. Requires vo=libmpv and the GL context current on the calling thread.
 func (m *Mpv) NewRenderContextGL(getProcAddress func(name string) unsafe.Pointer) (*RenderContext, error) {
+	return m.NewRenderContextGLWithParams(getProcAddress, nil)
+}
+
+// NewRenderContextGLWithParams is NewRenderContextGL with creation parameters, see RenderCreateParams.
+func (m *Mpv) NewRenderContextGLWithParams(getProcAddress func(name string) unsafe.Pointer, p *RenderCreateParams) (*RenderContext, error) {
 	ensureRenderCallbacks()
 	id := registerRenderCallbacks()
 	setRenderProcAddress(id, getProcAddress)
 
 	apiType := cStr(RenderAPITypeOpenGL)
 	gl := cOpenGLInitParams{getProcAddress: procAddrCb, ctx: id}
-	params := []cRenderParam{
+	params := appendIntParams([]cRenderParam{
 		{typ: renderParamAPIType, data: unsafe.Pointer(apiType)},
 		{typ: renderParamOpenGLInitParams, data: unsafe.Pointer(&gl)},
-		{},
-	}
+	}, p.ints())
 
 	var ctx uintptr
-	err := newError(renderContextCreate(unsafe.Pointer(&ctx), m.handle, unsafe.Pointer(&params[0])))
+	err := opError(renderContextCreate(unsafe.Pointer(&ctx), m.handle, unsafe.Pointer(&params[0])), "render_context_create", "", nil)
 	if err != nil {
 		unregisterRenderCallbacks(id)
 		return nil, err
@@ -128,35 +130,84 @@ func (m *Mpv) NewRenderContextGL(getProcAddress func(name string) unsafe.Pointer
 // RenderSW renders the current frame into buf, which must hold stride*height
 // bytes. format is one of "rgb0", "bgr0", "0bgr", "0rgb".
 func (rc *RenderContext) RenderSW(width, height, stride int, format string, buf []byte) error {
+	return rc.RenderSWWithParams(width, height, stride, format, buf, nil)
+}
+
+// RenderSWWithParams is RenderSW with render parameters, see RenderParams.
+func (rc *RenderContext) RenderSWWithParams(width, height, stride int, format string, buf []byte, p *RenderParams) error {
 	size := [2]int32{int32(width), int32(height)}
 	cformat := cStr(format)
 	cstride := uintptr(stride)
-	params := []cRenderParam{
+	params := appendIntParams([]cRenderParam{
 		{typ: renderParamSWSize, data: unsafe.Pointer(&size[0])},
 		{typ: renderParamSWFormat, data: unsafe.Pointer(cformat)},
 		{typ: renderParamSWStride, data: unsafe.Pointer(&cstride)},
 		{typ: renderParamSWPointer, data: unsafe.Pointer(&buf[0])},
-		{},
-	}
+	}, p.ints())
 
-	return newError(renderContextRender(rc.ctx, unsafe.Pointer(&params[0])))
+	return opError(renderContextRender(rc.ctx, unsafe.Pointer(&params[0])), "render_context_render", "", nil)
 }
 
 // RenderGL renders the current frame into the given OpenGL framebuffer object
 // (0 for the default framebuffer). Set flipY for bottom-up coordinate systems.
 func (rc *RenderContext) RenderGL(fbo, width, height int, flipY bool) error {
+	return rc.RenderGLWithParams(fbo, width, height, flipY, nil)
+}
+
+// RenderGLWithParams is RenderGL with render parameters, see RenderParams.
+func (rc *RenderContext) RenderGLWithParams(fbo, width, height int, flipY bool, p *RenderParams) error {
 	gl := cOpenGLFBO{fbo: int32(fbo), w: int32(width), h: int32(height)}
-	flip := int32(0)
-	if flipY {
-		flip = 1
-	}
-	params := []cRenderParam{
+	flip := boolInt(flipY)
+	params := appendIntParams([]cRenderParam{
 		{typ: renderParamOpenGLFBO, data: unsafe.Pointer(&gl)},
 		{typ: renderParamFlipY, data: unsafe.Pointer(&flip)},
-		{},
+	}, p.ints())
+
+	return opError(renderContextRender(rc.ctx, unsafe.Pointer(&params[0])), "render_context_render", "", nil)
+}
+
+// GetInfo returns information about the next frame (MPV_RENDER_PARAM_NEXT_FRAME_INFO),
+// mostly useful with RenderCreateParams.AdvancedControl.
+func (rc *RenderContext) GetInfo() (RenderFrameInfo, error) {
+	var info cRenderFrameInfo
+	param := cRenderParam{typ: renderParamNextFrameInfo, data: unsafe.Pointer(&info)}
+	if err := opError(renderGetInfo(rc.ctx, param), "render_context_get_info", "", nil); err != nil {
+		return RenderFrameInfo{}, err
+	}
+
+	return newRenderFrameInfo(info.flags, info.targetTime), nil
+}
+
+// SetICCProfile sets the ICC profile of the display, the contents of an ICC file. An empty
+// profile resets it. mpv copies the data. It only has an effect if the icc-profile-auto
+// option is enabled, and the SW renderer does not support it.
+func (rc *RenderContext) SetICCProfile(profile []byte) error {
+	var icc cByteArray
+	if len(profile) > 0 {
+		icc = cByteArray{data: unsafe.Pointer(&profile[0]), size: uintptr(len(profile))}
+	}
+	param := cRenderParam{typ: renderParamICCProfile, data: unsafe.Pointer(&icc)}
+
+	return opError(renderSetParameter(rc.ctx, param), "render_context_set_parameter", "icc-profile", nil)
+}
+
+// SetAmbientLight sets the ambient light in lux, used by the gamma-auto option. The SW
+// renderer does not support it.
+func (rc *RenderContext) SetAmbientLight(lux int) error {
+	clux := int32(lux)
+	param := cRenderParam{typ: renderParamAmbientLight, data: unsafe.Pointer(&clux)}
+
+	return opError(renderSetParameter(rc.ctx, param), "render_context_set_parameter", "ambient-light", nil)
+}
+
+// appendIntParams appends int parameter pairs and the terminator to params. The values
+// stay referenced by the returned slice.
+func appendIntParams(params []cRenderParam, ints []int32) []cRenderParam {
+	for i := 0; i+1 < len(ints); i += 2 {
+		params = append(params, cRenderParam{typ: ints[i], data: unsafe.Pointer(&ints[i+1])})
 	}
 
-	return newError(renderContextRender(rc.ctx, unsafe.Pointer(&params[0])))
+	return append(params, cRenderParam{})
 }
 
 // SetUpdateCallback sets fn to run when a new frame is ready. fn runs on an mpv
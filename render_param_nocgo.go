@@ -0,0 +1,29 @@
+//go:build (!cgo || nocgo) && !(windows && amd64)
+
+package mpv
+
+import (
+	"unsafe"
+
+	"github.com/ebitengine/purego"
+)
+
+// mpv_render_context_get_info and mpv_render_context_set_parameter take their
+// mpv_render_param by value. With the SysV and AArch64 calling conventions a 16 byte struct
+// of integers is passed in two registers, so the functions are bound with the fields as
+// separate arguments.
+var renderContextGetInfo func(ctx, typ uintptr, data unsafe.Pointer) int
+var renderContextSetParameter func(ctx, typ uintptr, data unsafe.Pointer) int
+
+func init() {
+	purego.RegisterLibFunc(&renderContextGetInfo, libmpv, "mpv_render_context_get_info")
+	purego.RegisterLibFunc(&renderContextSetParameter, libmpv, "mpv_render_context_set_parameter")
+}
+
+func renderGetInfo(ctx uintptr, param cRenderParam) int {
+	return renderContextGetInfo(ctx, uintptr(param.typ), param.data)
+}
+
+func renderSetParameter(ctx uintptr, param cRenderParam) int {
+	return renderContextSetParameter(ctx, uintptr(param.typ), param.data)
+}
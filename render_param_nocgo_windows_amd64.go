@@ -0,0 +1,29 @@
+//go:build (!cgo || nocgo) && windows && amd64
+
+package mpv
+
+import (
+	"unsafe"
+
+	"github.com/ebitengine/purego"
+)
+
+// mpv_render_context_get_info and mpv_render_context_set_parameter take their
+// mpv_render_param by value. The Windows x64 calling convention passes structs larger than
+// 8 bytes as a pointer to a caller owned copy, so the functions are bound with a pointer
+// argument.
+var renderContextGetInfo func(ctx uintptr, param unsafe.Pointer) int
+var renderContextSetParameter func(ctx uintptr, param unsafe.Pointer) int
+
+func init() {
+	purego.RegisterLibFunc(&renderContextGetInfo, libmpv, "mpv_render_context_get_info")
+	purego.RegisterLibFunc(&renderContextSetParameter, libmpv, "mpv_render_context_set_parameter")
+}
+
+func renderGetInfo(ctx uintptr, param cRenderParam) int {
+	return renderContextGetInfo(ctx, unsafe.Pointer(&param))
+}
+
+func renderSetParameter(ctx uintptr, param cRenderParam) int {
+	return renderContextSetParameter(ctx, unsafe.Pointer(&param))
+}
@@ -1,6 +1,8 @@
 package mpv
 
 import (
+	"errors"
+	"reflect"
 	"sync/atomic"
 	"testing"
 )
@@ -38,7 +40,8 @@ func TestRenderSW(t *testing.T) {
 			t.Fatal("file did not load")
 		}
 		if e.EventID == EventEnd {
-			t.Fatalf("playback ended before load: %v", e.EndFile().Reason)
+			ef, _ := e.EndFile()
+			t.Fatalf("playback ended before load: %v", ef.Reason)
 		}
 	}
 
@@ -105,3 +108,125 @@ func TestRenderUpdateCallback(t *testing.T) {
 
 	t.Fatal("update callback was not called")
 }
+
+func TestRenderParamsInts(t *testing.T) {
+	var cp *RenderCreateParams
+	var p *RenderParams
+	if cp.ints() != nil || p.ints() != nil {
+		t.Error("nil params produced ints")
+	}
+
+	cp = &RenderCreateParams{AdvancedControl: true}
+	if got, want := cp.ints(), []int32{renderParamAdvancedControl, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("create ints = %v, want %v", got, want)
+	}
+
+	block := false
+	p = &RenderParams{BlockForTargetTime: &block, SkipRendering: true, Depth: 10}
+	want := []int32{renderParamBlockForTargetTime, 0, renderParamSkipRendering, 1, renderParamDepth, 10}
+	if got := p.ints(); !reflect.DeepEqual(got, want) {
+		t.Errorf("render ints = %v, want %v", got, want)
+	}
+
+	info := newRenderFrameInfo(renderFrameInfoPresent|renderFrameInfoRepeat, 42)
+	if !info.Present || info.Redraw || !info.Repeat || info.BlockVsync || info.TargetTime != 42 {
+		t.Errorf("newRenderFrameInfo = %+v", info)
+	}
+}
+
+// newRenderSW returns an initialized instance with vo=libmpv and a SW render context.
+func newRenderSW(t *testing.T, p *RenderCreateParams) (*Mpv, *RenderContext) {
+	t.Helper()
+
+	m := New()
+	t.Cleanup(m.TerminateDestroy)
+
+	if err := m.SetOptionString("vo", "libmpv"); err != nil {
+		t.Fatalf("set vo=libmpv: %v", err)
+	}
+	if err := m.SetOptionString("ao", "null"); err != nil {
+		t.Fatalf("set ao=null: %v", err)
+	}
+	if err := m.Initialize(); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+
+	rc, err := m.NewRenderContextSWWithParams(p)
+	if err != nil {
+		t.Fatalf("NewRenderContextSWWithParams: %v", err)
+	}
+	// Registered after TerminateDestroy, so it runs first.
+	t.Cleanup(rc.Free)
+
+	return m, rc
+}
+
+func TestRenderAdvancedControl(t *testing.T) {
+	m, rc := newRenderSW(t, &RenderCreateParams{AdvancedControl: true})
+
+	var updates atomic.Int32
+	rc.SetUpdateCallback(func() { updates.Add(1) })
+
+	if err := m.Command([]string{"loadfile", "testdata/test.mpg"}); err != nil {
+		t.Fatalf("loadfile: %v", err)
+	}
+
+	const w, h, stride = 64, 48, 64 * 4
+	buf := make([]byte, stride*h)
+	noBlock := false
+
+	for i := 0; i < 200; i++ {
+		if updates.Load() > 0 && rc.Update()&RenderUpdateFrame != 0 {
+			info, err := rc.GetInfo()
+			if err != nil {
+				t.Fatalf("GetInfo: %v", err)
+			}
+			if info.Present {
+				// Skipping leaves the buffer alone but consumes the frame.
+				if err := rc.RenderSWWithParams(w, h, stride, "rgb0", buf, &RenderParams{SkipRendering: true, BlockForTargetTime: &noBlock}); err != nil {
+					t.Fatalf("RenderSWWithParams skip: %v", err)
+				}
+				for _, b := range buf {
+					if b != 0 {
+						t.Fatal("SkipRendering wrote to the buffer")
+					}
+				}
+				return
+			}
+		}
+		m.WaitEvent(0.05)
+	}
+
+	t.Fatal("no frame info with a present frame")
+}
+
+// TestRenderSetParameter checks that the SW renderer, which has no set_parameter, reports the
+// parameters as not implemented.
+func TestRenderSetParameter(t *testing.T) {
+	_, rc := newRenderSW(t, nil)
+
+	// Not a valid profile, the SW renderer never parses it.
+	icc := []byte("acspAPPL")
+	tests := []struct {
+		name  string
+		param string
+		set   func() error
+	}{
+		{"SetICCProfile", "icc-profile", func() error { return rc.SetICCProfile(icc) }},
+		{"SetICCProfile empty", "icc-profile", func() error { return rc.SetICCProfile(nil) }},
+		{"SetAmbientLight", "ambient-light", func() error { return rc.SetAmbientLight(250) }},
+	}
+
+	for _, tt := range tests {
+		err := tt.set()
+		if !errors.Is(err, ErrNotImplemented) {
+			t.Errorf("%s: err = %v, want ErrNotImplemented", tt.name, err)
+			continue
+		}
+
+		var e *Error
+		if !errors.As(err, &e) || e.Op != "render_context_set_parameter" || e.Name != tt.param {
+			t.Errorf("%s: err = %#v, want an *Error from render_context_set_parameter of %s", tt.name, err, tt.param)
+		}
+	}
+}
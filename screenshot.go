@@ -0,0 +1,149 @@
+package mpv
+
+import (
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+)
+
+// RawFormat is the pixel layout requested from the screenshot-raw command.
+type RawFormat string
+
+// Raw screenshot formats.
+const (
+	// RawBGR0 is 8 bit BGR with an unused fourth byte, converted to *image.RGBA.
+	RawBGR0 RawFormat = "bgr0"
+	// RawBGRA is 8 bit BGR with straight alpha, converted to *image.NRGBA.
+	RawBGRA RawFormat = "bgra"
+	// RawRGBA is 8 bit RGB with straight alpha, converted to *image.NRGBA.
+	RawRGBA RawFormat = "rgba"
+	// RawRGBA64 is 16 bit native endian RGB with straight alpha, converted to *image.RGBA64.
+	RawRGBA64 RawFormat = "rgba64"
+)
+
+// rawScreenshot is the result of screenshot-raw.
+type rawScreenshot struct {
+	W      int64     `mpv:"w"`
+	H      int64     `mpv:"h"`
+	Stride int64     `mpv:"stride"`
+	Format RawFormat `mpv:"format"`
+	Data   []byte    `mpv:"data"`
+}
+
+// Screenshot takes a screenshot in memory, see ScreenshotRaw. It uses RawBGR0, so the
+// image is an *image.RGBA without transparency.
+func (m *Mpv) Screenshot(mode ScreenshotMode) (image.Image, error) {
+	return m.ScreenshotRaw(mode, RawBGR0)
+}
+
+// ScreenshotRaw takes a screenshot with the screenshot-raw command in the given pixel format
+// and converts it to an image, without writing a file. It needs a decoded video frame, which
+// any video output provides, vo=null included. An empty format uses RawBGR0.
+func (m *Mpv) ScreenshotRaw(mode ScreenshotMode, format RawFormat) (image.Image, error) {
+	if mode == "" {
+		mode = ScreenshotSubtitles
+	}
+	if format == "" {
+		format = RawBGR0
+	}
+
+	res, err := m.CommandNode([]any{"screenshot-raw", string(mode), string(format)})
+	if err != nil {
+		return nil, err
+	}
+
+	var raw rawScreenshot
+	if err := DecodeNode(res, &raw); err != nil {
+		return nil, err
+	}
+
+	return raw.image()
+}
+
+// ScreenshotPNG takes a screenshot and encodes it as PNG to w.
+func (m *Mpv) ScreenshotPNG(w io.Writer, mode ScreenshotMode) error {
+	img, err := m.Screenshot(mode)
+	if err != nil {
+		return err
+	}
+
+	return png.Encode(w, img)
+}
+
+// ScreenshotJPEG takes a screenshot and encodes it as JPEG with the given quality, 1 to 100, to w.
+func (m *Mpv) ScreenshotJPEG(w io.Writer, mode ScreenshotMode, quality int) error {
+	img, err := m.Screenshot(mode)
+	if err != nil {
+		return err
+	}
+
+	return jpeg.Encode(w, img, &jpeg.Options{Quality: quality})
+}
+
+var rawBpp = map[RawFormat]int{
+	RawBGR0:   4,
+	RawBGRA:   4,
+	RawRGBA:   4,
+	RawRGBA64: 8,
+}
+
+// image converts the pixel data into the image type matching its format, honoring the stride.
+func (r *rawScreenshot) image() (image.Image, error) {
+	bpp, ok := rawBpp[r.Format]
+	if !ok {
+		return nil, fmt.Errorf("%w: unsupported screenshot format %q", ErrInvalidParameter, r.Format)
+	}
+
+	w, h, stride := int(r.W), int(r.H), int(r.Stride)
+	if w <= 0 || h <= 0 || stride < w*bpp || len(r.Data) < stride*(h-1)+w*bpp {
+		return nil, fmt.Errorf("%w: bad %s screenshot %dx%d, stride %d, %d bytes", ErrInvalidParameter, r.Format, w, h, stride, len(r.Data))
+	}
+
+	rect := image.Rect(0, 0, w, h)
+
+	switch r.Format {
+	case RawBGR0:
+		img := image.NewRGBA(rect)
+		swizzleRows(img.Pix, img.Stride, r.Data, stride, w, h, true)
+		for i := 3; i < len(img.Pix); i += 4 {
+			img.Pix[i] = 0xff
+		}
+		return img, nil
+	case RawBGRA, RawRGBA:
+		img := image.NewNRGBA(rect)
+		swizzleRows(img.Pix, img.Stride, r.Data, stride, w, h, r.Format == RawBGRA)
+		return img, nil
+	default:
+		img := image.NewRGBA64(rect)
+		for y := 0; y < h; y++ {
+			src := r.Data[y*stride : y*stride+w*8]
+			dst := img.Pix[y*img.Stride : y*img.Stride+w*8]
+			for x := 0; x < w*8; x += 8 {
+				a := uint32(binary.NativeEndian.Uint16(src[x+6:]))
+				for c := 0; c < 6; c += 2 {
+					v := uint32(binary.NativeEndian.Uint16(src[x+c:])) * a / 0xffff
+					binary.BigEndian.PutUint16(dst[x+c:], uint16(v))
+				}
+				binary.BigEndian.PutUint16(dst[x+6:], uint16(a))
+			}
+		}
+		return img, nil
+	}
+}
+
+// swizzleRows copies h rows of w 4-byte pixels, swapping the first and third byte if swap is set.
+func swizzleRows(dst []byte, dstStride int, src []byte, srcStride, w, h int, swap bool) {
+	for y := 0; y < h; y++ {
+		s := src[y*srcStride : y*srcStride+w*4]
+		d := dst[y*dstStride : y*dstStride+w*4]
+		copy(d, s)
+		if swap {
+			for x := 0; x < len(d); x += 4 {
+				d[x], d[x+2] = d[x+2], d[x]
+			}
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package mpv
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+)
+
+func TestRawScreenshotImage(t *testing.T) {
+	// 2x2 images with 4 bytes of row padding.
+	px8 := func(a, b, c, d byte) []byte {
+		row := []byte{a, b, c, d, a, b, c, d, 0xee, 0xee, 0xee, 0xee}
+		return append(append([]byte(nil), row...), row...)
+	}
+
+	tests := []struct {
+		raw  rawScreenshot
+		want color.Color
+		typ  image.Image
+	}{
+		{rawScreenshot{2, 2, 12, RawBGR0, px8(1, 2, 3, 0)}, color.RGBA{3, 2, 1, 0xff}, &image.RGBA{}},
+		{rawScreenshot{2, 2, 12, RawBGRA, px8(1, 2, 3, 4)}, color.NRGBA{3, 2, 1, 4}, &image.NRGBA{}},
+		{rawScreenshot{2, 2, 12, RawRGBA, px8(1, 2, 3, 4)}, color.NRGBA{1, 2, 3, 4}, &image.NRGBA{}},
+	}
+
+	px16 := make([]byte, 2*20)
+	for y := 0; y < 2; y++ {
+		for x := 0; x < 2; x++ {
+			for c, v := range []uint16{0x8000, 0x4000, 0xffff, 0x8000} {
+				binary.NativeEndian.PutUint16(px16[y*20+x*8+c*2:], v)
+			}
+		}
+	}
+	tests = append(tests, struct {
+		raw  rawScreenshot
+		want color.Color
+		typ  image.Image
+	}{rawScreenshot{2, 2, 20, RawRGBA64, px16}, color.RGBA64{0x4000, 0x2000, 0x8000, 0x8000}, &image.RGBA64{}})
+
+	for _, tt := range tests {
+		img, err := tt.raw.image()
+		if err != nil {
+			t.Fatalf("%s: %v", tt.raw.Format, err)
+		}
+		if gotT, wantT := fmt.Sprintf("%T", img), fmt.Sprintf("%T", tt.typ); gotT != wantT {
+			t.Errorf("%s: image type %s, want %s", tt.raw.Format, gotT, wantT)
+		}
+		if img.Bounds() != image.Rect(0, 0, 2, 2) {
+			t.Errorf("%s: bounds %v", tt.raw.Format, img.Bounds())
+		}
+		if got := img.At(1, 1); got != tt.want {
+			t.Errorf("%s: At(1, 1) = %#v, want %#v", tt.raw.Format, got, tt.want)
+		}
+	}
+
+	bad := rawScreenshot{2, 2, 4, RawBGR0, make([]byte, 16)}
+	if _, err := bad.image(); !errors.Is(err, ErrInvalidParameter) {
+		t.Errorf("short stride: err = %v, want ErrInvalidParameter", err)
+	}
+	bad = rawScreenshot{2, 2, 8, "yuv420p", make([]byte, 16)}
+	if _, err := bad.image(); !errors.Is(err, ErrInvalidParameter) {
+		t.Errorf("unknown format: err = %v, want ErrInvalidParameter", err)
+	}
+}
+
+func TestScreenshot(t *testing.T) {
+	m := newHeadless(t)
+
+	if err := m.LoadFile("testdata/test.mpg", LoadReplace, 0, map[string]string{"pause": "yes"}); err != nil {
+		t.Fatalf("LoadFile: %v", err)
+	}
+	waitFileLoaded(t, m)
+
+	vp, err := m.VideoParams()
+	if err != nil {
+		t.Fatalf("VideoParams: %v", err)
+	}
+
+	img, err := m.Screenshot(ScreenshotVideo)
+	if err != nil {
+		t.Fatalf("Screenshot: %v", err)
+	}
+	if _, ok := img.(*image.RGBA); !ok {
+		t.Errorf("Screenshot returned %T, want *image.RGBA", img)
+	}
+	if b := img.Bounds(); int64(b.Dx()) != vp.DW || int64(b.Dy()) != vp.DH {
+		t.Errorf("screenshot is %v, video is %dx%d", b, vp.DW, vp.DH)
+	}
+
+	var buf bytes.Buffer
+	if err := m.ScreenshotPNG(&buf, ScreenshotVideo); err != nil {
+		t.Fatalf("ScreenshotPNG: %v", err)
+	}
+	if _, err := png.Decode(&buf); err != nil {
+		t.Errorf("decoding the PNG: %v", err)
+	}
+}
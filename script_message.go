@@ -0,0 +1,45 @@
+package mpv
+
+// HandleScriptMessage calls fn for client messages whose first argument is name, passing the
+// remaining arguments, like mp.register_script_message in Lua scripts. A later call for the same
+// name replaces fn, a nil fn removes it. fn runs on the goroutine calling WaitEvent (or Events).
+//
+// Lua scripts send to this client with mp.commandv("script-message-to", <client name>, name, ...);
+// by convention the sender passes its own name as an argument, so fn can reply with ScriptMessageTo.
+func (m *Mpv) HandleScriptMessage(name string, fn func(args []string)) {
+	m.state.mu.Lock()
+	defer m.state.mu.Unlock()
+
+	if fn == nil {
+		delete(m.state.messages, name)
+		return
+	}
+
+	m.state.messages[name] = fn
+}
+
+// ScriptMessage broadcasts a message to all clients and scripts.
+func (m *Mpv) ScriptMessage(name string, args ...string) error {
+	return m.Command(append([]string{"script-message", name}, args...))
+}
+
+// ScriptMessageTo sends a message to the client or script with the given name.
+func (m *Mpv) ScriptMessageTo(target, name string, args ...string) error {
+	return m.Command(append([]string{"script-message-to", target, name}, args...))
+}
+
+// routeScriptMessage calls the handler registered for a client message, if any.
+func (m *Mpv) routeScriptMessage(e *Event) {
+	args, err := e.ClientMessage()
+	if err != nil || len(args) == 0 || m.routeKeyBinding(args) {
+		return
+	}
+
+	m.state.mu.Lock()
+	fn := m.state.messages[args[0]]
+	m.state.mu.Unlock()
+
+	if fn != nil {
+		fn(args[1:])
+	}
+}
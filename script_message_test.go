@@ -0,0 +1,61 @@
+package mpv
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestHandleScriptMessage(t *testing.T) {
+	m := newHeadless(t)
+
+	peer, err := m.CreateClient("peer")
+	if err != nil {
+		t.Fatalf("CreateClient: %v", err)
+	}
+	defer peer.Destroy()
+
+	// The peer answers pings to the sender named in the first argument.
+	peer.HandleScriptMessage("ping", func(args []string) {
+		if err := peer.ScriptMessageTo(args[0], "pong", args[1:]...); err != nil {
+			t.Errorf("ScriptMessageTo: %v", err)
+		}
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := drain(peer.Events(ctx))
+	defer func() { cancel(); <-done }()
+
+	var got []string
+	m.HandleScriptMessage("pong", func(args []string) {
+		got = args
+	})
+
+	if err := m.ScriptMessageTo("peer", "ping", m.Name(), "a", "b"); err != nil {
+		t.Fatalf("ScriptMessageTo: %v", err)
+	}
+
+	for i := 0; i < 100 && got == nil; i++ {
+		if e := m.WaitEvent(1); e.EventID == EventNone {
+			break
+		}
+	}
+	if !reflect.DeepEqual(got, []string{"a", "b"}) {
+		t.Fatalf("pong args = %#v, want [a b]", got)
+	}
+
+	m.HandleScriptMessage("pong", nil)
+	got = nil
+	if err := m.ScriptMessage("pong", "c"); err != nil {
+		t.Fatalf("ScriptMessage: %v", err)
+	}
+	for {
+		e := m.WaitEvent(1)
+		if e.EventID == EventClientMessage || e.EventID == EventNone {
+			break
+		}
+	}
+	if got != nil {
+		t.Fatalf("removed handler was called with %#v", got)
+	}
+}
@@ -0,0 +1,205 @@
+package mpv
+
+import (
+	"errors"
+	"io"
+	"sync"
+	"sync/atomic"
+)
+
+// StreamSizer can be implemented by a stream reader to report its size directly,
+// otherwise the size is found by seeking to the end.
+type StreamSizer interface {
+	Size() int64
+}
+
+// StreamCanceler can be implemented by a stream reader to abort a blocked Read
+// when mpv cancels the stream, e.g. on stop or quit.
+type StreamCanceler interface {
+	Cancel()
+}
+
+// Protocols and open streams live in token-keyed registries, same as the render
+// callbacks; the tokens are passed to C as the user data and stream cookie.
+var (
+	streamMu       sync.Mutex
+	streamProtos   = map[uintptr]func(uri string) (io.ReadSeekCloser, error){}
+	streamProtoSeq uintptr
+	streams        = map[uintptr]*stream{}
+	streamSeq      uintptr
+)
+
+type stream struct {
+	r        io.ReadSeekCloser
+	canceled atomic.Bool
+}
+
+func registerStreamProtocol(open func(uri string) (io.ReadSeekCloser, error)) uintptr {
+	streamMu.Lock()
+	defer streamMu.Unlock()
+
+	streamProtoSeq++
+	streamProtos[streamProtoSeq] = open
+
+	return streamProtoSeq
+}
+
+func unregisterStreamProtocol(id uintptr) {
+	streamMu.Lock()
+	defer streamMu.Unlock()
+
+	delete(streamProtos, id)
+}
+
+// addProtocol records the registry token of a protocol registered through m.
+func (m *Mpv) addProtocol(id uintptr) {
+	m.state.mu.Lock()
+	defer m.state.mu.Unlock()
+
+	m.state.protocols = append(m.state.protocols, id)
+}
+
+// unregisterProtocols removes the protocols registered through m from the registry,
+// called once the handle is destroyed.
+func (m *Mpv) unregisterProtocols() {
+	m.state.mu.Lock()
+	ids := m.state.protocols
+	m.state.protocols = nil
+	m.state.mu.Unlock()
+
+	for _, id := range ids {
+		unregisterStreamProtocol(id)
+	}
+}
+
+func lookupStream(cookie uintptr) *stream {
+	streamMu.Lock()
+	defer streamMu.Unlock()
+
+	return streams[cookie]
+}
+
+// dispatchStreamOpen opens a stream for the protocol token and returns the cookie
+// for the new stream, or an mpv error code.
+func dispatchStreamOpen(id uintptr, uri string) (uintptr, int) {
+	streamMu.Lock()
+	open := streamProtos[id]
+	streamMu.Unlock()
+
+	if open == nil {
+		return 0, errorLoadingFailed
+	}
+
+	r, err := open(uri)
+	if err != nil || r == nil {
+		return 0, errorLoadingFailed
+	}
+
+	streamMu.Lock()
+	defer streamMu.Unlock()
+
+	streamSeq++
+	streams[streamSeq] = &stream{r: r}
+
+	return streamSeq, errorSuccess
+}
+
+// maxEmptyReads is how often dispatchStreamRead retries a Read that returns no data and
+// no error before giving up, the same limit bufio uses.
+const maxEmptyReads = 100
+
+// dispatchStreamRead reads into buf and returns the number of bytes read, 0 on EOF or -1 on error.
+// A Read returning no data and no error is retried, since 0 would end the stream.
+func dispatchStreamRead(cookie uintptr, buf []byte) int64 {
+	s := lookupStream(cookie)
+	if s == nil {
+		return -1
+	}
+
+	for i := 0; i < maxEmptyReads; i++ {
+		if s.canceled.Load() {
+			return -1
+		}
+
+		n, err := s.r.Read(buf)
+		if n > 0 {
+			return int64(n)
+		}
+		if errors.Is(err, io.EOF) {
+			return 0
+		}
+		if err != nil {
+			return -1
+		}
+	}
+
+	return -1
+}
+
+// dispatchStreamSeek seeks to the absolute offset and returns the new position or an mpv error code.
+func dispatchStreamSeek(cookie uintptr, offset int64) int64 {
+	s := lookupStream(cookie)
+	if s == nil || s.canceled.Load() {
+		return errorGeneric
+	}
+
+	pos, err := s.r.Seek(offset, io.SeekStart)
+	if err != nil {
+		return errorGeneric
+	}
+
+	return pos
+}
+
+// dispatchStreamSize returns the stream size, or errorUnsupported if it is unknown.
+func dispatchStreamSize(cookie uintptr) int64 {
+	s := lookupStream(cookie)
+	if s == nil {
+		return errorUnsupported
+	}
+
+	if sz, ok := s.r.(StreamSizer); ok {
+		if size := sz.Size(); size >= 0 {
+			return size
+		}
+		return errorUnsupported
+	}
+
+	cur, err := s.r.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return errorUnsupported
+	}
+	end, err := s.r.Seek(0, io.SeekEnd)
+	if err != nil {
+		return errorUnsupported
+	}
+	if _, err := s.r.Seek(cur, io.SeekStart); err != nil {
+		return errorUnsupported
+	}
+
+	return end
+}
+
+func dispatchStreamClose(cookie uintptr) {
+	streamMu.Lock()
+	s := streams[cookie]
+	delete(streams, cookie)
+	streamMu.Unlock()
+
+	if s != nil {
+		_ = s.r.Close()
+	}
+}
+
+// dispatchStreamCancel may run on another thread while a read is blocked.
+func dispatchStreamCancel(cookie uintptr) {
+	s := lookupStream(cookie)
+	if s == nil {
+		return
+	}
+
+	s.canceled.Store(true)
+	if c, ok := s.r.(StreamCanceler); ok {
+		c.Cancel()
+	}
+}
@@ -0,0 +1,62 @@
+//go:build cgo && !nocgo
+
+package mpv
+
+/*
+#include <mpv/client.h>
+#include <mpv/stream_cb.h>
+#include <stdlib.h>
+#include <stdint.h>
+
+int goMpvStreamOpen(void *user_data, char *uri, uintptr_t *cookie);
+int64_t goMpvStreamRead(void *cookie, char *buf, uint64_t nbytes);
+int64_t goMpvStreamSeek(void *cookie, int64_t offset);
+int64_t goMpvStreamSize(void *cookie);
+void goMpvStreamClose(void *cookie);
+void goMpvStreamCancel(void *cookie);
+
+static int stream_open(void *user_data, char *uri, mpv_stream_cb_info *info) {
+    uintptr_t cookie = 0;
+    int err = goMpvStreamOpen(user_data, uri, &cookie);
+    if (err < 0) {
+        return err;
+    }
+    info->cookie = (void *)cookie;
+    info->read_fn = goMpvStreamRead;
+    info->seek_fn = goMpvStreamSeek;
+    info->size_fn = goMpvStreamSize;
+    info->close_fn = goMpvStreamClose;
+    info->cancel_fn = goMpvStreamCancel;
+    return 0;
+}
+
+static int stream_cb_add_ro(mpv_handle *mpv, const char *protocol, uintptr_t id) {
+    return mpv_stream_cb_add_ro(mpv, protocol, (void *)id, stream_open);
+}
+*/
+import "C"
+
+import (
+	"io"
+	"unsafe"
+)
+
+// RegisterProtocol adds a custom protocol; "scheme://..." URLs are then read through
+// the reader returned by open, which mpv closes once it is done with the stream.
+// The protocol stays registered until the handle it was registered through is destroyed,
+// opening its URLs fails after that.
+func (m *Mpv) RegisterProtocol(scheme string, open func(uri string) (io.ReadSeekCloser, error)) error {
+	id := registerStreamProtocol(open)
+
+	cscheme := C.CString(scheme)
+	defer C.free(unsafe.Pointer(cscheme))
+
+	err := opError(int(C.stream_cb_add_ro(m.handle, cscheme, C.uintptr_t(id))), "stream_cb_add_ro", scheme, nil)
+	if err != nil {
+		unregisterStreamProtocol(id)
+		return err
+	}
+	m.addProtocol(id)
+
+	return nil
+}
@@ -0,0 +1,45 @@
+//go:build cgo && !nocgo
+
+package mpv
+
+/*
+#include <stdint.h>
+*/
+import "C"
+
+import (
+	"unsafe"
+)
+
+//export goMpvStreamOpen
+func goMpvStreamOpen(userData unsafe.Pointer, uri *C.char, cookie *C.uintptr_t) C.int {
+	id, err := dispatchStreamOpen(uintptr(userData), C.GoString(uri))
+	*cookie = C.uintptr_t(id)
+
+	return C.int(err)
+}
+
+//export goMpvStreamRead
+func goMpvStreamRead(cookie unsafe.Pointer, buf *C.char, nbytes C.uint64_t) C.int64_t {
+	return C.int64_t(dispatchStreamRead(uintptr(cookie), unsafe.Slice((*byte)(unsafe.Pointer(buf)), int(nbytes))))
+}
+
+//export goMpvStreamSeek
+func goMpvStreamSeek(cookie unsafe.Pointer, offset C.int64_t) C.int64_t {
+	return C.int64_t(dispatchStreamSeek(uintptr(cookie), int64(offset)))
+}
+
+//export goMpvStreamSize
+func goMpvStreamSize(cookie unsafe.Pointer) C.int64_t {
+	return C.int64_t(dispatchStreamSize(uintptr(cookie)))
+}
+
+//export goMpvStreamClose
+func goMpvStreamClose(cookie unsafe.Pointer) {
+	dispatchStreamClose(uintptr(cookie))
+}
+
+//export goMpvStreamCancel
+func goMpvStreamCancel(cookie unsafe.Pointer) {
+	dispatchStreamCancel(uintptr(cookie))
+}
@@ -0,0 +1,94 @@
+//go:build !cgo || nocgo
+
+package mpv
+
+import (
+	"io"
+	"sync"
+	"unsafe"
+
+	"github.com/ebitengine/purego"
+)
+
+// cStreamCbInfo mirrors C mpv_stream_cb_info.
+type cStreamCbInfo struct {
+	cookie   uintptr
+	readFn   uintptr
+	seekFn   uintptr
+	sizeFn   uintptr
+	closeFn  uintptr
+	cancelFn uintptr
+}
+
+var streamCbAddRo func(handle uintptr, protocol string, userData, openFn uintptr) int
+
+func init() {
+	purego.RegisterLibFunc(&streamCbAddRo, libmpv, "mpv_stream_cb_add_ro")
+}
+
+// Created once; the trampolines dispatch by the protocol token and stream cookie.
+var (
+	streamCbOnce   sync.Once
+	streamOpenCb   uintptr
+	streamReadCb   uintptr
+	streamSeekCb   uintptr
+	streamSizeCb   uintptr
+	streamCloseCb  uintptr
+	streamCancelCb uintptr
+)
+
+func ensureStreamCallbacks() {
+	streamCbOnce.Do(func() {
+		streamReadCb = purego.NewCallback(func(cookie uintptr, buf unsafe.Pointer, nbytes uint64) uintptr {
+			return uintptr(dispatchStreamRead(cookie, unsafe.Slice((*byte)(buf), int(nbytes))))
+		})
+		streamSeekCb = purego.NewCallback(func(cookie uintptr, offset int64) uintptr {
+			return uintptr(dispatchStreamSeek(cookie, offset))
+		})
+		streamSizeCb = purego.NewCallback(func(cookie uintptr) uintptr {
+			return uintptr(dispatchStreamSize(cookie))
+		})
+		streamCloseCb = purego.NewCallback(func(cookie uintptr) uintptr {
+			dispatchStreamClose(cookie)
+			return 0
+		})
+		streamCancelCb = purego.NewCallback(func(cookie uintptr) uintptr {
+			dispatchStreamCancel(cookie)
+			return 0
+		})
+		streamOpenCb = purego.NewCallback(func(userData uintptr, uri *byte, info unsafe.Pointer) uintptr {
+			cookie, err := dispatchStreamOpen(userData, toStr(unsafe.Pointer(uri)))
+			if err < 0 {
+				return uintptr(int32(err))
+			}
+
+			ci := (*cStreamCbInfo)(info)
+			ci.cookie = cookie
+			ci.readFn = streamReadCb
+			ci.seekFn = streamSeekCb
+			ci.sizeFn = streamSizeCb
+			ci.closeFn = streamCloseCb
+			ci.cancelFn = streamCancelCb
+
+			return 0
+		})
+	})
+}
+
+// RegisterProtocol adds a custom protocol; "scheme://..." URLs are then read through
+// the reader returned by open, which mpv closes once it is done with the stream.
+// The protocol stays registered until the handle it was registered through is destroyed,
+// opening its URLs fails after that.
+func (m *Mpv) RegisterProtocol(scheme string, open func(uri string) (io.ReadSeekCloser, error)) error {
+	ensureStreamCallbacks()
+	id := registerStreamProtocol(open)
+
+	err := opError(streamCbAddRo(m.handle, scheme, id, streamOpenCb), "stream_cb_add_ro", scheme, nil)
+	if err != nil {
+		unregisterStreamProtocol(id)
+		return err
+	}
+	m.addProtocol(id)
+
+	return nil
+}
@@ -0,0 +1,178 @@
+package mpv
+
+import (
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// countingFile counts the reads mpv makes through the stream callbacks.
+type countingFile struct {
+	*os.File
+	reads atomic.Int32
+}
+
+func (f *countingFile) Read(p []byte) (int, error) {
+	f.reads.Add(1)
+	return f.File.Read(p)
+}
+
+func TestRegisterProtocol(t *testing.T) {
+	m := newHeadless(t)
+
+	// The open callback runs on an mpv thread.
+	var (
+		mu     sync.Mutex
+		f      *countingFile
+		gotURI string
+	)
+	err := m.RegisterProtocol("gotest", func(uri string) (io.ReadSeekCloser, error) {
+		file, err := os.Open("testdata/test.mpg")
+		if err != nil {
+			return nil, err
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		gotURI = uri
+		f = &countingFile{File: file}
+		return f, nil
+	})
+	if err != nil {
+		t.Fatalf("RegisterProtocol: %v", err)
+	}
+
+	if err := m.Command([]string{"loadfile", "gotest://test.mpg"}); err != nil {
+		t.Fatalf("loadfile: %v", err)
+	}
+
+	loaded := false
+	for {
+		e := m.WaitEvent(10)
+		switch e.EventID {
+		case EventFileLoaded:
+			loaded = true
+		case EventEnd:
+			ef, err := e.EndFile()
+			if err != nil {
+				t.Fatalf("EndFile: %v", err)
+			}
+			if ef.Reason != EndFileEOF {
+				t.Fatalf("end reason = %v (%v), want eof", ef.Reason, ef.Error)
+			}
+			if !loaded {
+				t.Fatal("playback ended before the file loaded")
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if gotURI != "gotest://test.mpg" {
+				t.Errorf("open uri = %q, want gotest://test.mpg", gotURI)
+			}
+			if f == nil || f.reads.Load() == 0 {
+				t.Error("reader was never read")
+			}
+			return
+		case EventNone, EventShutdown:
+			t.Fatal("file did not play through the Go reader")
+		}
+	}
+}
+
+func TestRegisterProtocolOpenError(t *testing.T) {
+	m := newHeadless(t)
+
+	err := m.RegisterProtocol("gofail", func(uri string) (io.ReadSeekCloser, error) {
+		return nil, os.ErrNotExist
+	})
+	if err != nil {
+		t.Fatalf("RegisterProtocol: %v", err)
+	}
+
+	if err := m.Command([]string{"loadfile", "gofail://missing"}); err != nil {
+		t.Fatalf("loadfile: %v", err)
+	}
+
+	for {
+		e := m.WaitEvent(10)
+		switch e.EventID {
+		case EventEnd:
+			if ef, err := e.EndFile(); err != nil || ef.Reason != EndFileError {
+				t.Fatalf("end reason = %v (%v), want error", ef.Reason, err)
+			}
+			return
+		case EventNone, EventShutdown:
+			t.Fatal("no end-file event")
+		}
+	}
+}
+
+func TestRegisterProtocolDestroy(t *testing.T) {
+	m := New()
+	if err := m.Initialize(); err != nil {
+		t.Fatalf("Initialize: %v", err)
+	}
+
+	err := m.RegisterProtocol("godestroy", func(uri string) (io.ReadSeekCloser, error) {
+		return nil, os.ErrNotExist
+	})
+	if err != nil {
+		m.TerminateDestroy()
+		t.Fatalf("RegisterProtocol: %v", err)
+	}
+	id := m.state.protocols[0]
+
+	m.TerminateDestroy()
+
+	streamMu.Lock()
+	_, ok := streamProtos[id]
+	streamMu.Unlock()
+	if ok {
+		t.Error("protocol still registered after TerminateDestroy")
+	}
+}
+
+// emptyReader returns no data and no error for the first empty reads.
+type emptyReader struct {
+	io.ReadSeeker
+	empty int
+}
+
+func (r *emptyReader) Read(p []byte) (int, error) {
+	if r.empty > 0 {
+		r.empty--
+		return 0, nil
+	}
+	return r.ReadSeeker.Read(p)
+}
+
+func (r *emptyReader) Close() error { return nil }
+
+func TestStreamReadEmpty(t *testing.T) {
+	tests := []struct {
+		empty int
+		want  int64
+	}{
+		{0, 5},
+		{3, 5},
+		{maxEmptyReads, -1},
+	}
+
+	for _, tt := range tests {
+		streamMu.Lock()
+		streamSeq++
+		cookie := streamSeq
+		streams[cookie] = &stream{r: &emptyReader{ReadSeeker: strings.NewReader("hello"), empty: tt.empty}}
+		streamMu.Unlock()
+
+		buf := make([]byte, 16)
+		if got := dispatchStreamRead(cookie, buf); got != tt.want {
+			t.Errorf("%d empty reads: read = %d, want %d", tt.empty, got, tt.want)
+		}
+
+		streamMu.Lock()
+		delete(streams, cookie)
+		streamMu.Unlock()
+	}
+}
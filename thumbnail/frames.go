@@ -0,0 +1,234 @@
+package thumbnail
+
+import (
+	"context"
+	"errors"
+	"image"
+	"io"
+	"iter"
+	"strconv"
+	"time"
+
+	"github.com/gen2brain/go-mpv"
+)
+
+// SampleMode selects which frames Frames yields.
+type SampleMode int
+
+// Sample modes.
+const (
+	// SampleEvery yields the decoded frames in order while the file plays untimed.
+	SampleEvery SampleMode = iota
+	// SampleInterval yields one frame every FrameOptions.Interval, seeking exactly to each.
+	SampleInterval
+)
+
+// FrameOptions configures Frames.
+type FrameOptions struct {
+	// Width and Height are the bounding box the frames are scaled into, see Extractor.Frame.
+	Width, Height int
+
+	Mode SampleMode
+	// Interval is the distance between frames for SampleInterval, it must be positive.
+	Interval time.Duration
+
+	// Start is the position of the first frame, End the position after which no more
+	// frames are yielded. A zero End means the end of the file.
+	Start, End time.Duration
+
+	// Reuse renders every frame into the same image instead of allocating a new one.
+	// Frame.Image is then only valid until the loop continues, copy it to keep it.
+	Reuse bool
+}
+
+// Frame is a decoded video frame.
+type Frame struct {
+	// PTS is the presentation timestamp of the frame.
+	PTS time.Duration
+	// Number is mpv's estimated-frame-number, computed from the PTS and the container frame
+	// rate. It is an estimate: with a variable frame rate it can skip or repeat numbers.
+	Number int64
+	// Image is an *image.RGBA, see FrameOptions.Reuse.
+	Image image.Image
+}
+
+// Frames decodes path and yields its frames in order. With SampleEvery the file plays untimed
+// with display-desync video sync, so frames come as fast as decoding allows; mpv waits for
+// each frame to be rendered and yielded, but moves on after about 200ms, so a loop body that
+// takes longer skips frames. The size of all frames is fixed by the first one. Iteration
+// stops at the end of the file, at FrameOptions.End, when ctx is done or after the first
+// error, which is yielded with a zero Frame.
+func Frames(ctx context.Context, path string, opts FrameOptions) iter.Seq2[Frame, error] {
+	return func(yield func(Frame, error) bool) {
+		if opts.Mode == SampleInterval && opts.Interval <= 0 {
+			yield(Frame{}, errors.New("thumbnail: SampleInterval needs a positive Interval"))
+			return
+		}
+
+		s, err := newFrameSource(opts)
+		if err != nil {
+			yield(Frame{}, err)
+			return
+		}
+		defer s.Close()
+
+		for {
+			f, err := s.next(ctx, path)
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				yield(Frame{}, err)
+				return
+			}
+			if !yield(f, nil) {
+				return
+			}
+		}
+	}
+}
+
+// frameSource renders the frames of one file.
+type frameSource struct {
+	*player
+	opts FrameOptions
+
+	img      *image.RGBA
+	loaded   bool
+	playing  bool
+	at       time.Duration
+	duration time.Duration
+}
+
+func newFrameSource(opts FrameOptions) (*frameSource, error) {
+	p, err := newPlayer(
+		mpv.RawOption{Name: "keep-open", Value: "no"},
+		mpv.RawOption{Name: "untimed", Value: "yes"},
+		mpv.RawOption{Name: "video-sync", Value: "display-desync"},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &frameSource{player: p, opts: opts}, nil
+}
+
+// next returns the next frame, or io.EOF after the last one.
+func (s *frameSource) next(ctx context.Context, path string) (Frame, error) {
+	switch {
+	case !s.loaded:
+		return s.load(ctx, path)
+	case s.opts.Mode == SampleEvery:
+		if !s.playing {
+			if err := s.m.SetPause(false); err != nil {
+				return Frame{}, err
+			}
+			s.playing = true
+		}
+		return s.play(ctx)
+	default:
+		s.at += s.opts.Interval
+		if s.duration > 0 && s.at >= s.duration || s.opts.End > 0 && s.at > s.opts.End {
+			return Frame{}, io.EOF
+		}
+		err := s.settle(ctx, func() error {
+			return s.m.Seek(s.at.Seconds(), mpv.SeekAbsolute|mpv.SeekExact)
+		})
+		if err != nil {
+			return Frame{}, err
+		}
+		return s.draw()
+	}
+}
+
+// load loads the file paused at Start and returns its first frame.
+func (s *frameSource) load(ctx context.Context, path string) (Frame, error) {
+	var options map[string]string
+	if s.opts.Start > 0 {
+		options = map[string]string{"start": strconv.FormatFloat(s.opts.Start.Seconds(), 'f', -1, 64)}
+	}
+
+	err := s.settle(ctx, func() error {
+		return s.m.LoadFile(path, mpv.LoadReplace, 0, options)
+	})
+	if err == io.EOF || errors.Is(err, mpv.ErrNothingToPlay) {
+		return Frame{}, ErrNoVideo
+	} else if err != nil {
+		return Frame{}, err
+	}
+
+	f, err := s.draw()
+	if err != nil {
+		return Frame{}, err
+	}
+
+	s.loaded = true
+	s.at = s.opts.Start
+
+	s.duration, err = s.m.Duration()
+	if err != nil && !errors.Is(err, mpv.ErrPropertyUnavailable) {
+		return Frame{}, err
+	}
+
+	return f, nil
+}
+
+// play waits for the next frame mpv hands over while playing and renders it.
+func (s *frameSource) play(ctx context.Context) (Frame, error) {
+	for {
+		e, frame, err := s.await(ctx, nil)
+		if err != nil {
+			return Frame{}, err
+		}
+		if frame {
+			return s.draw()
+		}
+
+		if e.id == mpv.EventEnd {
+			if e.err != nil {
+				return Frame{}, e.err
+			}
+			return Frame{}, io.EOF
+		}
+	}
+}
+
+// draw renders the current frame into the image and reads its position. Until the swap is
+// reported mpv does not present the next frame, so the position belongs to the rendered one.
+func (s *frameSource) draw() (Frame, error) {
+	switch {
+	case s.img == nil:
+		w, h, err := displaySize(s.m)
+		if err != nil {
+			return Frame{}, err
+		}
+		w, h = Fit(w, h, s.opts.Width, s.opts.Height)
+		s.img = image.NewRGBA(image.Rect(0, 0, w, h))
+	case !s.opts.Reuse:
+		s.img = image.NewRGBA(s.img.Rect)
+	}
+
+	if err := s.rc.RenderSW(s.img.Rect.Dx(), s.img.Rect.Dy(), s.img.Stride, "rgb0", s.img.Pix); err != nil {
+		return Frame{}, err
+	}
+	defer s.rc.ReportSwap()
+
+	for i := 3; i < len(s.img.Pix); i += 4 {
+		s.img.Pix[i] = 0xff
+	}
+
+	pts, err := s.m.TimePos()
+	if err != nil {
+		return Frame{}, err
+	}
+	if s.opts.End > 0 && pts > s.opts.End {
+		return Frame{}, io.EOF
+	}
+
+	n, err := s.m.EstimatedFrameNumber()
+	if err != nil && !errors.Is(err, mpv.ErrPropertyUnavailable) {
+		return Frame{}, err
+	}
+
+	return Frame{PTS: pts, Number: n, Image: s.img}, nil
+}
@@ -0,0 +1,82 @@
+package thumbnail
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestFramesEvery(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	var (
+		n    int
+		last Frame
+	)
+	for f, err := range Frames(ctx, testFile, FrameOptions{Width: 64, End: time.Second}) {
+		if err != nil {
+			t.Fatalf("Frames: %v", err)
+		}
+		if n > 0 && f.PTS <= last.PTS {
+			t.Errorf("frame %d: pts %v not after %v", n, f.PTS, last.PTS)
+		}
+		if n > 0 && f.Image == last.Image {
+			t.Errorf("frame %d: image reused without Reuse", n)
+		}
+		if f.Image.Bounds().Dx() != 64 {
+			t.Errorf("frame %d: width = %d, want 64", n, f.Image.Bounds().Dx())
+		}
+		last = f
+		n++
+	}
+
+	if n < 2 {
+		t.Fatalf("got %d frames", n)
+	}
+	if last.PTS > time.Second {
+		t.Errorf("last pts %v after End", last.PTS)
+	}
+}
+
+func TestFramesInterval(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	opts := FrameOptions{Width: 64, Mode: SampleInterval, Interval: 500 * time.Millisecond, End: 2 * time.Second, Reuse: true}
+
+	var pts []time.Duration
+	var first Frame
+	for f, err := range Frames(ctx, testFile, opts) {
+		if err != nil {
+			t.Fatalf("Frames: %v", err)
+		}
+		if len(pts) == 0 {
+			first = f
+		} else if f.Image != first.Image {
+			t.Error("image not reused with Reuse")
+		}
+		pts = append(pts, f.PTS)
+		if len(pts) == 3 {
+			break
+		}
+	}
+
+	if len(pts) != 3 {
+		t.Fatalf("got %d frames, want 3", len(pts))
+	}
+	for i, p := range pts {
+		want := time.Duration(i) * opts.Interval
+		if d := p - want; d < -50*time.Millisecond || d > 50*time.Millisecond {
+			t.Errorf("frame %d: pts %v, want about %v", i, p, want)
+		}
+	}
+}
+
+func TestFramesBadOptions(t *testing.T) {
+	for _, err := range Frames(context.Background(), testFile, FrameOptions{Mode: SampleInterval}) {
+		if err == nil {
+			t.Fatal("SampleInterval without Interval succeeded")
+		}
+	}
+}
@@ -0,0 +1,466 @@
+// Package thumbnail extracts poster frames, timeline thumbnails and decoded frame sequences
+// from video files with a headless mpv, rendering in software so it needs no GPU or display.
+package thumbnail
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"io"
+	"math"
+	"time"
+
+	"github.com/gen2brain/go-mpv"
+)
+
+// ErrNoVideo is returned by Open for files without a video track.
+var ErrNoVideo = errors.New("thumbnail: file has no video")
+
+// errShutdown is returned when the mpv instance shuts down while waiting for a frame.
+var errShutdown = errors.New("thumbnail: mpv shut down")
+
+// errTimeout is returned by await when the timeout channel fires.
+var errTimeout = errors.New("thumbnail: timed out")
+
+// frameTimeout is how long settle waits after playback restarts for mpv to hand over the
+// frame at the restart position, if it has not done so before.
+const frameTimeout = time.Second
+
+// Extractor renders frames of one file at a time with a dedicated mpv instance.
+// It is not safe for concurrent use, see Pool for that.
+type Extractor struct {
+	*player
+
+	width, height int
+	duration      time.Duration
+}
+
+// New creates an extractor with vo=libmpv, ao=null and a software render context.
+func New() (*Extractor, error) {
+	p, err := newPlayer()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Extractor{player: p}, nil
+}
+
+// playerEvent is the part of an mpv event the player needs, copied off the event loop.
+type playerEvent struct {
+	id  mpv.EventID
+	err error
+}
+
+// player is a headless mpv instance with a software render context. Events are read by a
+// goroutine so that waiting for them can be combined with the update callback and a context.
+type player struct {
+	m  *mpv.Mpv
+	rc *mpv.RenderContext
+
+	events  chan playerEvent
+	updates chan struct{}
+	stop    chan struct{}
+	done    chan struct{}
+
+	scratch []byte
+}
+
+// newPlayer creates a paused, silent mpv instance without subtitles and a software render
+// context for it. raw options are set after the defaults.
+func newPlayer(raw ...mpv.RawOption) (*player, error) {
+	m, err := mpv.NewWithOptions(&mpv.Options{
+		VO:          "libmpv",
+		AO:          "null",
+		HWDec:       "no",
+		Config:      mpv.Ptr(false),
+		LoadScripts: mpv.Ptr(false),
+		OSC:         mpv.Ptr(false),
+		YTDL:        mpv.Ptr(false),
+		Terminal:    mpv.Ptr(false),
+		Idle:        mpv.IdleYes,
+		KeepOpen:    mpv.KeepOpenAlways,
+		Pause:       mpv.Ptr(true),
+		Raw: append([]mpv.RawOption{
+			{Name: "aid", Value: "no"},
+			{Name: "sid", Value: "no"},
+			{Name: "hr-seek", Value: "yes"},
+		}, raw...),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rc, err := m.NewRenderContextSW()
+	if err != nil {
+		m.TerminateDestroy()
+		return nil, err
+	}
+
+	p := &player{
+		m:       m,
+		rc:      rc,
+		events:  make(chan playerEvent),
+		updates: make(chan struct{}, 1),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+		scratch: make([]byte, 16*16*4),
+	}
+
+	rc.SetUpdateCallback(func() {
+		select {
+		case p.updates <- struct{}{}:
+		default:
+		}
+	})
+
+	go p.readEvents()
+
+	return p, nil
+}
+
+// Close frees the render context and destroys the mpv instance.
+func (p *player) Close() {
+	close(p.stop)
+	p.m.Wakeup()
+	<-p.done
+
+	p.rc.Free()
+	p.m.TerminateDestroy()
+}
+
+func (p *player) readEvents() {
+	defer close(p.done)
+
+	for {
+		select {
+		case <-p.stop:
+			return
+		default:
+		}
+
+		e := p.m.WaitEvent(-1)
+		pe := playerEvent{id: e.EventID}
+
+		switch e.EventID {
+		case mpv.EventNone:
+			continue
+		case mpv.EventEnd:
+			ef, err := e.EndFile()
+			if err != nil {
+				pe.err = err
+			} else if ef.Reason == mpv.EndFileError {
+				pe.err = ef.Error
+			}
+		}
+
+		select {
+		case p.events <- pe:
+		case <-p.stop:
+			return
+		}
+
+		if e.EventID == mpv.EventShutdown {
+			return
+		}
+	}
+}
+
+// await blocks until the next event or render update. For an update it returns a zero event
+// and whether mpv has a new frame to render. It fails when ctx is done, timeout fires or mpv
+// shuts down; a nil timeout never fires.
+func (p *player) await(ctx context.Context, timeout <-chan time.Time) (playerEvent, bool, error) {
+	select {
+	case <-ctx.Done():
+		return playerEvent{}, false, ctx.Err()
+	case <-timeout:
+		return playerEvent{}, false, errTimeout
+	case <-p.updates:
+		return playerEvent{}, p.rc.Update()&mpv.RenderUpdateFrame != 0, nil
+	case e := <-p.events:
+		if e.id == mpv.EventShutdown {
+			return e, false, errShutdown
+		}
+		return e, false, nil
+	}
+}
+
+// settle runs start, a command that restarts playback such as a seek, and waits until the
+// playback restart. Frames handed over before it are rendered away. With hr-seek mpv hands
+// over no frames before the target, so the frame current at the restart is the target if one
+// was handed over since start ran, which settle waits for. It returns io.EOF if playback ends
+// without an error.
+func (p *player) settle(ctx context.Context, start func() error) error {
+	// A frame handed over before start would be taken for the new one.
+	if p.rc.Update()&mpv.RenderUpdateFrame != 0 {
+		if err := p.discard(); err != nil {
+			return err
+		}
+	}
+
+	if err := start(); err != nil {
+		return err
+	}
+
+	var fresh, restarted bool
+	var timeout <-chan time.Time
+	for !restarted || !fresh {
+		e, frame, err := p.await(ctx, timeout)
+		if errors.Is(err, errTimeout) {
+			return fmt.Errorf("thumbnail: no frame within %v of the playback restart", frameTimeout)
+		} else if err != nil {
+			return err
+		}
+
+		if frame {
+			fresh = true
+			if !restarted {
+				if err := p.discard(); err != nil {
+					return err
+				}
+			}
+		}
+
+		switch e.id {
+		case mpv.EventPlaybackRestart:
+			restarted = true
+			timeout = time.After(frameTimeout)
+		case mpv.EventEnd:
+			if e.err != nil {
+				return e.err
+			}
+			return io.EOF
+		}
+	}
+
+	return nil
+}
+
+// discard renders the current frame into a tiny scratch buffer, so that the VO does not stall
+// on a frame nobody wants.
+func (p *player) discard() error {
+	if err := p.rc.RenderSW(4, 4, 16, "rgb0", p.scratch); err != nil {
+		return err
+	}
+	p.rc.ReportSwap()
+
+	return nil
+}
+
+// Open loads the file and waits for its first frame, replacing the previous file.
+func (x *Extractor) Open(ctx context.Context, path string) error {
+	if err := x.m.LoadFile(path, mpv.LoadReplace, 0, nil); err != nil {
+		return err
+	}
+
+	for loaded := false; ; {
+		e, _, err := x.await(ctx, nil)
+		if err != nil {
+			return err
+		}
+
+		switch e.id {
+		case mpv.EventFileLoaded:
+			loaded = true
+		case mpv.EventEnd:
+			// The end of the previous file is reported too, before the new one is loaded.
+			// With aid=no a file without video has nothing to play.
+			switch {
+			case errors.Is(e.err, mpv.ErrNothingToPlay):
+				return ErrNoVideo
+			case e.err != nil:
+				return fmt.Errorf("thumbnail: cannot open %s: %w", path, e.err)
+			case loaded:
+				return ErrNoVideo
+			}
+		}
+		if loaded && e.id == mpv.EventPlaybackRestart {
+			break
+		}
+	}
+
+	var err error
+	x.width, x.height, err = displaySize(x.m)
+	if err != nil {
+		return err
+	}
+
+	x.duration, err = x.m.Duration()
+	if err != nil && !errors.Is(err, mpv.ErrPropertyUnavailable) {
+		return err
+	}
+
+	return nil
+}
+
+// displaySize returns the size of the current video with aspect ratio and rotation applied.
+func displaySize(m *mpv.Mpv) (int, int, error) {
+	vp, err := m.VideoParams()
+	if errors.Is(err, mpv.ErrPropertyUnavailable) || err == nil && (vp.DW <= 0 || vp.DH <= 0) {
+		return 0, 0, ErrNoVideo
+	} else if err != nil {
+		return 0, 0, err
+	}
+
+	if vp.Rotate%180 == 90 {
+		return int(vp.DH), int(vp.DW), nil
+	}
+
+	return int(vp.DW), int(vp.DH), nil
+}
+
+// Size returns the display size of the open video, with aspect ratio and rotation applied.
+func (x *Extractor) Size() (width, height int) {
+	return x.width, x.height
+}
+
+// Duration returns the duration of the open file, or 0 if it is unknown.
+func (x *Extractor) Duration() time.Duration {
+	return x.duration
+}
+
+// Frame seeks to at and renders the frame scaled to fit into width x height, preserving the
+// aspect ratio. If width or height is 0 it is computed from the other, if both are 0 the
+// frame is rendered at the display size of the video.
+func (x *Extractor) Frame(ctx context.Context, at time.Duration, width, height int) (image.Image, error) {
+	if x.width == 0 {
+		return nil, fmt.Errorf("%w: no file is open", mpv.ErrInvalidParameter)
+	}
+
+	w, h := Fit(x.width, x.height, width, height)
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+
+	err := x.settle(ctx, func() error {
+		return x.m.Seek(at.Seconds(), mpv.SeekAbsolute|mpv.SeekExact)
+	})
+	if err == io.EOF {
+		return nil, fmt.Errorf("thumbnail: playback ended while seeking to %v", at)
+	} else if err != nil {
+		return nil, err
+	}
+
+	if err := x.rc.RenderSW(w, h, img.Stride, "rgb0", img.Pix); err != nil {
+		return nil, err
+	}
+	x.rc.ReportSwap()
+
+	for i := 3; i < len(img.Pix); i += 4 {
+		img.Pix[i] = 0xff
+	}
+
+	return img, nil
+}
+
+// Fit returns the size of a w x h image scaled to fit into maxW x maxH, preserving the aspect
+// ratio. A zero maximum is unconstrained, if both are zero the size is unchanged.
+func Fit(w, h, maxW, maxH int) (int, int) {
+	if w <= 0 || h <= 0 || maxW <= 0 && maxH <= 0 {
+		return w, h
+	}
+
+	scale := math.Inf(1)
+	if maxW > 0 {
+		scale = float64(maxW) / float64(w)
+	}
+	if maxH > 0 {
+		scale = min(scale, float64(maxH)/float64(h))
+	}
+
+	return max(1, int(math.Round(float64(w)*scale))), max(1, int(math.Round(float64(h)*scale)))
+}
+
+// Extract opens path with a new extractor and renders a frame for each of times.
+func Extract(ctx context.Context, path string, times []time.Duration, width, height int) ([]image.Image, error) {
+	x, err := New()
+	if err != nil {
+		return nil, err
+	}
+	defer x.Close()
+
+	return x.extract(ctx, path, times, width, height)
+}
+
+func (x *Extractor) extract(ctx context.Context, path string, times []time.Duration, width, height int) ([]image.Image, error) {
+	if err := x.Open(ctx, path); err != nil {
+		return nil, err
+	}
+
+	images := make([]image.Image, len(times))
+	for i, at := range times {
+		img, err := x.Frame(ctx, at, width, height)
+		if err != nil {
+			return nil, err
+		}
+		images[i] = img
+	}
+
+	return images, nil
+}
+
+// Pool is a set of extractors that are reused across files. It is safe for concurrent use,
+// Extract blocks until an extractor is free.
+type Pool struct {
+	// free holds the idle extractors, nil for one that failed and is created again on use.
+	free chan *Extractor
+	size int
+}
+
+// NewPool creates a pool of n extractors.
+func NewPool(n int) (*Pool, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("%w: pool size %d", mpv.ErrInvalidParameter, n)
+	}
+
+	p := &Pool{free: make(chan *Extractor, n), size: n}
+	for i := 0; i < n; i++ {
+		x, err := New()
+		if err != nil {
+			p.close(i)
+			return nil, err
+		}
+		p.free <- x
+	}
+
+	return p, nil
+}
+
+// Extract renders a frame of path for each of times with a free extractor, see Extract.
+// An extractor that fails is closed instead of being reused.
+func (p *Pool) Extract(ctx context.Context, path string, times []time.Duration, width, height int) ([]image.Image, error) {
+	var x *Extractor
+	select {
+	case x = <-p.free:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	if x == nil {
+		var err error
+		if x, err = New(); err != nil {
+			p.free <- nil
+			return nil, err
+		}
+	}
+
+	images, err := x.extract(ctx, path, times, width, height)
+	if err != nil {
+		x.Close()
+		x = nil
+	}
+	p.free <- x
+
+	return images, err
+}
+
+// Close waits until all extractors are free and closes them.
+func (p *Pool) Close() {
+	p.close(p.size)
+}
+
+func (p *Pool) close(n int) {
+	for i := 0; i < n; i++ {
+		if x := <-p.free; x != nil {
+			x.Close()
+		}
+	}
+}
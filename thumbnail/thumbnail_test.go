@@ -0,0 +1,167 @@
+package thumbnail
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"image"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+const testFile = "../testdata/test.mpg"
+
+func TestFit(t *testing.T) {
+	tests := []struct {
+		w, h, maxW, maxH int
+		wantW, wantH     int
+	}{
+		{640, 480, 320, 240, 320, 240},
+		{640, 480, 320, 0, 320, 240},
+		{640, 480, 0, 120, 160, 120},
+		{640, 480, 200, 200, 200, 150},
+		{480, 640, 200, 200, 150, 200},
+		{640, 480, 0, 0, 640, 480},
+		{1920, 1080, 1, 1, 1, 1},
+	}
+
+	for _, tt := range tests {
+		w, h := Fit(tt.w, tt.h, tt.maxW, tt.maxH)
+		if w != tt.wantW || h != tt.wantH {
+			t.Errorf("Fit(%d, %d, %d, %d) = %dx%d, want %dx%d", tt.w, tt.h, tt.maxW, tt.maxH, w, h, tt.wantW, tt.wantH)
+		}
+	}
+}
+
+func TestExtract(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	x, err := New()
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer x.Close()
+
+	if err := x.Open(ctx, testFile); err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+
+	vw, vh := x.Size()
+	if vw <= 0 || vh <= 0 {
+		t.Fatalf("Size = %dx%d", vw, vh)
+	}
+
+	wantW, wantH := Fit(vw, vh, 160, 160)
+	for _, at := range []time.Duration{0, time.Second, 500 * time.Millisecond} {
+		img, err := x.Frame(ctx, at, 160, 160)
+		if err != nil {
+			t.Fatalf("Frame(%v): %v", at, err)
+		}
+		if b := img.Bounds(); b.Dx() != wantW || b.Dy() != wantH {
+			t.Errorf("Frame(%v) size = %dx%d, want %dx%d", at, b.Dx(), b.Dy(), wantW, wantH)
+		}
+		if !hasContent(img) {
+			t.Errorf("Frame(%v) is black", at)
+		}
+	}
+
+	if _, err := x.Frame(ctx, time.Second, 0, 0); err != nil {
+		t.Fatalf("Frame at native size: %v", err)
+	}
+}
+
+func TestPool(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	p, err := NewPool(2)
+	if err != nil {
+		t.Fatalf("NewPool: %v", err)
+	}
+	defer p.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			images, err := p.Extract(ctx, testFile, []time.Duration{0, time.Second}, 64, 0)
+			if err != nil {
+				t.Errorf("Extract: %v", err)
+				return
+			}
+			for _, img := range images {
+				if img.Bounds().Dx() != 64 {
+					t.Errorf("width = %d, want 64", img.Bounds().Dx())
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if _, err := p.Extract(ctx, "nonexistent.mkv", []time.Duration{0}, 64, 0); err == nil {
+		t.Error("Extract of a missing file succeeded")
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := p.Extract(ctx, testFile, []time.Duration{0}, 64, 0); err != nil {
+			t.Fatalf("Extract after a failure: %v", err)
+		}
+	}
+}
+
+func TestOpenAudioOnly(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	x, err := New()
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer x.Close()
+
+	if err := x.Open(ctx, writeWAV(t)); !errors.Is(err, ErrNoVideo) {
+		t.Fatalf("Open of an audio file: err = %v, want ErrNoVideo", err)
+	}
+}
+
+// writeWAV writes one second of 8 kHz mono silence and returns its path.
+func writeWAV(t *testing.T) string {
+	t.Helper()
+
+	const rate, samples = 8000, 8000
+	var buf bytes.Buffer
+	buf.WriteString("RIFF")
+	_ = binary.Write(&buf, binary.LittleEndian, uint32(36+2*samples))
+	buf.WriteString("WAVEfmt ")
+	for _, v := range []any{uint32(16), uint16(1), uint16(1), uint32(rate), uint32(2 * rate), uint16(2), uint16(16)} {
+		_ = binary.Write(&buf, binary.LittleEndian, v)
+	}
+	buf.WriteString("data")
+	_ = binary.Write(&buf, binary.LittleEndian, uint32(2*samples))
+	buf.Write(make([]byte, 2*samples))
+
+	name := filepath.Join(t.TempDir(), "audio.wav")
+	if err := os.WriteFile(name, buf.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	return name
+}
+
+func hasContent(img image.Image) bool {
+	b := img.Bounds()
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			if r, g, bl, _ := img.At(x, y).RGBA(); r|g|bl != 0 {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,103 @@
+package mpv
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrTypeMismatch is returned when a Go value does not match the requested format.
+var ErrTypeMismatch = errors.New("go type does not match format")
+
+// Value is the set of Go types supported by Get and Set.
+type Value interface {
+	string | bool | int64 | float64 | []any | map[string]any | []byte
+}
+
+// formatOf returns the format used to transfer values of type T.
+func formatOf[T Value]() Format {
+	var zero T
+
+	switch any(zero).(type) {
+	case string:
+		return FormatString
+	case bool:
+		return FormatFlag
+	case int64:
+		return FormatInt64
+	case float64:
+		return FormatDouble
+	default:
+		return FormatNode
+	}
+}
+
+// Get returns the value of the property, with the format inferred from T.
+func Get[T Value](m *Mpv, name string) (T, error) {
+	var zero T
+
+	v, err := m.GetProperty(name, formatOf[T]())
+	if err != nil {
+		return zero, err
+	}
+
+	t, ok := v.(T)
+	if !ok {
+		return zero, fmt.Errorf("%w: property %s is %T, not %T", ErrTypeMismatch, name, v, zero)
+	}
+
+	return t, nil
+}
+
+// Set sets the property to v, with the format inferred from T.
+func Set[T Value](m *Mpv, name string, v T) error {
+	return m.SetProperty(name, formatOf[T](), v)
+}
+
+// PropertyString returns the value of the property as a string, unlike GetPropertyString
+// it returns the error if the property cannot be read.
+func (m *Mpv) PropertyString(name string) (string, error) {
+	v, err := m.GetProperty(name, FormatString)
+	if err != nil {
+		return "", err
+	}
+
+	return v.(string), nil
+}
+
+// PropertyOsdString returns the value of the property as a string formatted for on-screen display,
+// unlike GetPropertyOsdString it returns the error if the property cannot be read.
+func (m *Mpv) PropertyOsdString(name string) (string, error) {
+	v, err := m.GetProperty(name, FormatOsdString)
+	if err != nil {
+		return "", err
+	}
+
+	return v.(string), nil
+}
+
+// checkData reports whether data has a Go type that convertData accepts for format.
+func checkData(format Format, data interface{}) error {
+	var ok bool
+
+	switch format {
+	case FormatString, FormatOsdString:
+		_, ok = data.(string)
+	case FormatFlag:
+		_, ok = data.(bool)
+	case FormatInt64:
+		switch data.(type) {
+		case int64, int:
+			ok = true
+		}
+	case FormatDouble:
+		_, ok = data.(float64)
+	default:
+		ok = true
+	}
+
+	if !ok {
+		return fmt.Errorf("%w: %T for format %d", ErrTypeMismatch, data, format)
+	}
+
+	return nil
+}
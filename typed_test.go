@@ -0,0 +1,74 @@
+package mpv
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestGetSet(t *testing.T) {
+	m := newHeadless(t)
+
+	if err := Set(m, "force-media-title", "typed"); err != nil {
+		t.Fatal(err)
+	}
+	if v, err := Get[string](m, "force-media-title"); err != nil || v != "typed" {
+		t.Fatalf("Get[string] = %q, %v", v, err)
+	}
+
+	if err := Set(m, "pause", true); err != nil {
+		t.Fatal(err)
+	}
+	if v, err := Get[bool](m, "pause"); err != nil || !v {
+		t.Fatalf("Get[bool] = %v, %v", v, err)
+	}
+
+	if err := Set(m, "ab-loop-count", int64(4)); err != nil {
+		t.Fatal(err)
+	}
+	if v, err := Get[int64](m, "ab-loop-count"); err != nil || v != 4 {
+		t.Fatalf("Get[int64] = %v, %v", v, err)
+	}
+
+	if err := Set(m, "speed", 1.25); err != nil {
+		t.Fatal(err)
+	}
+	if v, err := Get[float64](m, "speed"); err != nil || v != 1.25 {
+		t.Fatalf("Get[float64] = %v, %v", v, err)
+	}
+
+	if err := Set(m, "script-opts", map[string]any{"a": "1"}); err != nil {
+		t.Fatal(err)
+	}
+	if v, err := Get[map[string]any](m, "script-opts"); err != nil || !reflect.DeepEqual(v, map[string]any{"a": "1"}) {
+		t.Fatalf("Get[map[string]any] = %#v, %v", v, err)
+	}
+
+	if err := Set(m, "alang", []any{"en", "de"}); err != nil {
+		t.Fatal(err)
+	}
+	if v, err := Get[[]any](m, "alang"); err != nil || !reflect.DeepEqual(v, []any{"en", "de"}) {
+		t.Fatalf("Get[[]any] = %#v, %v", v, err)
+	}
+
+	if _, err := Get[[]any](m, "script-opts"); !errors.Is(err, ErrTypeMismatch) {
+		t.Fatalf("Get[[]any] of a map = %v, want ErrTypeMismatch", err)
+	}
+	if _, err := Get[string](m, "does-not-exist"); !errors.Is(err, ErrPropertyNotFound) {
+		t.Fatalf("Get of missing property = %v, want ErrPropertyNotFound", err)
+	}
+}
+
+func TestSetPropertyTypeMismatch(t *testing.T) {
+	m := newHeadless(t)
+
+	if err := m.SetProperty("speed", FormatDouble, 2); !errors.Is(err, ErrTypeMismatch) {
+		t.Fatalf("SetProperty int as double = %v, want ErrTypeMismatch", err)
+	}
+	if err := m.SetOption("pause", FormatFlag, "yes"); !errors.Is(err, ErrTypeMismatch) {
+		t.Fatalf("SetOption string as flag = %v, want ErrTypeMismatch", err)
+	}
+	if err := m.SetPropertyAsync("volume", 1, FormatInt64, 1.5); !errors.Is(err, ErrTypeMismatch) {
+		t.Fatalf("SetPropertyAsync double as int64 = %v, want ErrTypeMismatch", err)
+	}
+}